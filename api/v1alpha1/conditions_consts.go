@@ -6,4 +6,120 @@ package v1alpha1
 const (
 	// IroncoreMetalClusterReady documents the status of IroncoreMetalCluster and its underlying resources.
 	IroncoreMetalClusterReady string = "ClusterReady"
+
+	// ControlPlaneEndpointReadyCondition documents the allocation of the control plane endpoint from an IPAM pool.
+	ControlPlaneEndpointReadyCondition string = "ControlPlaneEndpointReady"
+
+	// ControlPlaneEndpointAllocatedReason is used when the control plane endpoint has been allocated.
+	ControlPlaneEndpointAllocatedReason string = "Allocated"
+	// WaitingForControlPlaneEndpointReason is used while the IPAddressClaim of the control plane endpoint is not fulfilled.
+	WaitingForControlPlaneEndpointReason string = "WaitingForControlPlaneEndpoint"
+	// ControlPlaneEndpointAllocationFailedReason is used when the control plane endpoint could not be allocated.
+	ControlPlaneEndpointAllocationFailedReason string = "AllocationFailed"
+)
+
+// IroncoreMetalMachine conditions and their reasons.
+const (
+	// BootstrapDataReadyCondition documents the availability of the bootstrap data secret of the owning Machine.
+	BootstrapDataReadyCondition string = "BootstrapDataReady"
+
+	// BootstrapDataAvailableReason is used when the bootstrap data secret was read successfully.
+	BootstrapDataAvailableReason string = "BootstrapDataAvailable"
+	// WaitingForBootstrapDataReason is used when the Machine has no bootstrap data secret reference yet.
+	WaitingForBootstrapDataReason string = "WaitingForBootstrapData"
+	// BootstrapDataSecretUnavailableReason is used when the bootstrap data secret could not be read.
+	BootstrapDataSecretUnavailableReason string = "BootstrapDataSecretUnavailable"
+
+	// IPAddressesAllocatedCondition documents the allocation of the IP addresses requested via IPAMConfig.
+	IPAddressesAllocatedCondition string = "IPAddressesAllocated"
+
+	// IPAddressesAllocatedReason is used when all IPAddressClaims have an IPAddress assigned.
+	IPAddressesAllocatedReason string = "Allocated"
+	// WaitingForIPAMReason is used while IPAddressClaims have no IPAddress assigned yet.
+	WaitingForIPAMReason string = "WaitingForIPAM"
+	// IPAddressClaimFailedReason is used when the IPAddressClaims could not be created or resolved.
+	IPAddressClaimFailedReason string = "IPAddressClaimFailed"
+
+	// IgnitionReadyCondition documents the rendering of the ignition and its secret.
+	IgnitionReadyCondition string = "IgnitionReady"
+
+	// IgnitionCreatedReason is used when the ignition secret is up to date.
+	IgnitionCreatedReason string = "IgnitionCreated"
+	// IgnitionDeletedReason is used when the ignition secret has been deleted by the IgnitionSecretPolicy after the
+	// node joined the cluster.
+	IgnitionDeletedReason string = "IgnitionDeleted"
+	// IgnitionRedactedReason is used when the ignition secret has been redacted by the IgnitionSecretPolicy after the
+	// node joined the cluster.
+	IgnitionRedactedReason string = "IgnitionRedacted"
+	// IgnitionCreationFailedReason is used when the ignition could not be rendered or stored.
+	IgnitionCreationFailedReason string = "IgnitionCreationFailed"
+	// UnresolvedVariablesReason is used when the bootstrap data references variables without a value.
+	UnresolvedVariablesReason string = "UnresolvedVariables"
+
+	// ServerClaimBoundCondition documents whether the ServerClaim of the IroncoreMetalMachine is bound to a Server.
+	ServerClaimBoundCondition string = "ServerClaimBound"
+
+	// ServerClaimBoundReason is used when the ServerClaim is bound to a Server.
+	ServerClaimBoundReason string = "Bound"
+	// WaitingForClusterInfrastructureReason is used when the Cluster infrastructure is not provisioned yet.
+	WaitingForClusterInfrastructureReason string = "WaitingForClusterInfrastructure"
+	// WaitingForServerClaimBindingReason is used when the ServerClaim exists but has not been bound yet.
+	WaitingForServerClaimBindingReason string = "WaitingForBinding"
+	// ServerClaimFailedReason is used when the ServerClaim could not be created or updated.
+	ServerClaimFailedReason string = "ServerClaimFailed"
+	// ServerClaimDeletingReason is used while the ServerClaim is being deleted.
+	ServerClaimDeletingReason string = "Deleting"
+	// MetalClusterUnavailableReason is used when the metal cluster of the MetalKubeconfigSecretRef can not be reached,
+	// e.g. because its kubeconfig is missing or invalid.
+	MetalClusterUnavailableReason string = "MetalClusterUnavailable"
+	// MetalObjectsOrphanedReason is used when a deleting IroncoreMetalMachine leaves its ServerClaim and ignition secret
+	// behind in an unavailable metal cluster, as allowed by the OrphanMetalObjectsAnnotation.
+	MetalObjectsOrphanedReason string = "MetalObjectsOrphaned"
+	// InsufficientCapacityReason is used while no unclaimed Server matches the ServerSelector and the Tolerations of
+	// the unbound ServerClaim.
+	InsufficientCapacityReason string = "InsufficientCapacity"
+
+	// ProvisioningFailedCondition is set on the Machine of an IroncoreMetalMachine which can not be provisioned, e.g.
+	// because no Server became available within the insufficient capacity timeout. A MachineHealthCheck remediates
+	// such a Machine with an unhealthyMachineCondition of type ProvisioningFailed, status True and a timeoutSeconds of 0.
+	ProvisioningFailedCondition string = "ProvisioningFailed"
+
+	// ProvisioningRecoveredReason is used when a failed IroncoreMetalMachine is provisioned again, because a Server
+	// became available before the Machine was remediated.
+	ProvisioningRecoveredReason string = "Recovered"
+
+	// DriftedCondition documents whether the ignition or the ServerClaim rendered for a provisioned
+	// IroncoreMetalMachine differ from the ones its Server was provisioned with. The machine has to be replaced to
+	// apply them.
+	DriftedCondition string = "Drifted"
+
+	// NotDriftedReason is used when the rendered ignition and ServerClaim match the provisioned ones.
+	NotDriftedReason string = "NotDrifted"
+	// IgnitionDriftedReason is used when the rendered ignition differs from the provisioned one.
+	IgnitionDriftedReason string = "IgnitionChanged"
+	// ServerClaimDriftedReason is used when the rendered ServerClaim differs from the provisioned one.
+	ServerClaimDriftedReason string = "ServerClaimChanged"
+
+	// ServerReadyCondition documents the state of the Server bound to the ServerClaim.
+	ServerReadyCondition string = "ServerReady"
+
+	// ServerPoweredOnReason is used when the bound Server reports that it is powered on.
+	ServerPoweredOnReason string = "PoweredOn"
+	// WaitingForServerReason is used when the ServerClaim does not reference a Server yet.
+	WaitingForServerReason string = "WaitingForServer"
+	// WaitingForServerPowerOnReason is used when the bound Server is not powered on yet.
+	WaitingForServerPowerOnReason string = "WaitingForPowerOn"
+	// ServerPoweredOffReason is used when the bound Server is powered off as requested by the PowerAnnotation.
+	ServerPoweredOffReason string = "PoweredOff"
+	// WaitingForServerMaintenanceReason is used while the Server is moved into the maintenance requested by the
+	// KeepForInspection DeletionPolicy.
+	WaitingForServerMaintenanceReason string = "WaitingForMaintenance"
+	// WaitingForDiskWipeReason is used while the disks of the Server are wiped as requested by the ReleaseAfterDiskWipe
+	// DeletionPolicy.
+	WaitingForDiskWipeReason string = "WaitingForDiskWipe"
+	// DiskWipeTimedOutReason is used when the disks of the Server were not wiped within the disk wipe timeout. The
+	// ServerMaintenance is kept to quarantine the Server.
+	DiskWipeTimedOutReason string = "DiskWipeTimedOut"
+	// WaitingForServerReleaseReason is used while the Server is being released and powered off after deletion.
+	WaitingForServerReleaseReason string = "WaitingForRelease"
 )
@@ -13,16 +13,171 @@ const (
 	// ClusterFinalizer allows IroncoreMetalClusterReconciler to clean up resources associated with IroncoreMetalCluster before
 	// removing it from the apiserver.
 	ClusterFinalizer = "ironcoremetalcluster.infrastructure.cluster.x-k8s.io"
+
+	// MetalKubeconfigSecretKey is the key of the kubeconfig in the Secret referenced by the MetalKubeconfigSecretRef.
+	MetalKubeconfigSecretKey = "kubeconfig"
+
+	// OrphanMetalObjectsAnnotation allows the deletion of the IroncoreMetalMachines of an IroncoreMetalCluster whose
+	// metal cluster can not be reached, e.g. because the kubeconfig referenced by the MetalKubeconfigSecretRef is
+	// missing or invalid. Their ServerClaims and ignition secrets are left behind in the metal cluster then.
+	OrphanMetalObjectsAnnotation = "ironcoremetalcluster.infrastructure.cluster.x-k8s.io/orphan-metal-objects"
 )
 
 // IroncoreMetalClusterSpec defines the desired state of IroncoreMetalCluster
 type IroncoreMetalClusterSpec struct {
-	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
+	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane. The port defaults to
+	// 6443, or to 8443 with a Keepalived ControlPlaneLoadBalancer, as haproxy listens on it next to the kube-apiserver.
 	// +optional
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint,omitempty"`
+
+	// ControlPlaneEndpointIPAM configures the allocation of the ControlPlaneEndpoint host as a virtual IP from an IPAM pool.
+	// The allocated address is only written to ControlPlaneEndpoint if its host is empty.
+	// +optional
+	ControlPlaneEndpointIPAM *ControlPlaneEndpointIPAMConfig `json:"controlPlaneEndpointIPAM,omitempty"`
+
+	// ControlPlaneLoadBalancer configures the static pods announcing the host of the ControlPlaneEndpoint as a
+	// virtual IP. They are added to the ignition of control plane machines.
+	// +optional
+	ControlPlaneLoadBalancer *ControlPlaneLoadBalancer `json:"controlPlaneLoadBalancer,omitempty"`
+
 	// Cluster network configuration.
 	// +optional
 	ClusterNetwork clusterv1.ClusterNetwork `json:"clusterNetwork,omitempty"`
+
+	// FailureDomains maps failure domains, e.g. racks or rooms, to the labels of the Servers which belong to them.
+	// The ServerSelector of a failure domain is added to the ServerClaims of the machines placed into it.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	FailureDomains []FailureDomain `json:"failureDomains,omitempty"`
+
+	// DNS configures the nameservers and search domains of the machines. They are added to the metadata of the
+	// machines and can be overridden per network by annotating the IPAM pools or IPAddresses.
+	// +optional
+	DNS *DNSConfig `json:"dns,omitempty"`
+
+	// NTP configures the time servers of the machines. They are added to the metadata of the machines and can be
+	// overridden per network by annotating the IPAM pools or IPAddresses.
+	// +optional
+	NTP *NTPConfig `json:"ntp,omitempty"`
+
+	// MetalKubeconfigSecretRef references the kubeconfig of a remote cluster running the metal-operator. The
+	// ServerClaims and ignition secrets of the machines are created in that cluster instead of the management cluster.
+	// It can only be set on creation and is immutable. The kubeconfig has to allow watching the Servers and the
+	// ServerClaims, ServerMaintenances and secrets in the TargetNamespace. IroncoreMetalMachines are not deleted while
+	// the metal cluster can not be reached, unless the OrphanMetalObjectsAnnotation is set.
+	// +optional
+	MetalKubeconfigSecretRef *MetalKubeconfigSecretReference `json:"metalKubeconfigSecretRef,omitempty"`
+}
+
+// MetalKubeconfigSecretReference references the kubeconfig of a remote cluster running the metal-operator.
+type MetalKubeconfigSecretReference struct {
+	// Name is the name of the Secret in the namespace of the IroncoreMetalCluster. The kubeconfig is read from its
+	// `kubeconfig` key.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// TargetNamespace is the namespace of the ServerClaims and ignition secrets in the remote cluster. Defaults to the
+	// namespace of the IroncoreMetalCluster. It may be shared by several clusters, the objects are named after the
+	// namespace and the name of their IroncoreMetalMachine and labelled with them.
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+}
+
+// DNSConfig describes the DNS resolution of the machines of a cluster.
+type DNSConfig struct {
+	// Nameservers are the IP addresses of the DNS servers.
+	// +optional
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// SearchDomains are the DNS search domains.
+	// +optional
+	SearchDomains []string `json:"searchDomains,omitempty"`
+
+	// ConfigureResolved adds a systemd-resolved drop-in with the nameservers and search domains to the ignition.
+	// +optional
+	ConfigureResolved bool `json:"configureResolved,omitempty"`
+}
+
+// NTPConfig describes the time synchronization of the machines of a cluster.
+type NTPConfig struct {
+	// Servers are the IP addresses or hostnames of the NTP servers.
+	// +optional
+	Servers []string `json:"servers,omitempty"`
+
+	// ConfigureTimesyncd adds a systemd-timesyncd drop-in with the servers to the ignition.
+	// +optional
+	ConfigureTimesyncd bool `json:"configureTimesyncd,omitempty"`
+}
+
+// FailureDomain selects the Servers of a failure domain.
+type FailureDomain struct {
+	// Name is the name of the failure domain.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ControlPlane determines if the failure domain is suitable for control plane machines.
+	// +optional
+	ControlPlane bool `json:"controlPlane,omitempty"`
+
+	// ServerSelector specifies matching criteria for labels on the Servers of the failure domain.
+	ServerSelector metav1.LabelSelector `json:"serverSelector"`
+}
+
+// ControlPlaneEndpointIPAMConfig describes how the virtual IP of the control plane endpoint is allocated.
+type ControlPlaneEndpointIPAMConfig struct {
+	// IPAMRef is a reference to the IPAM pool the virtual IP is allocated from.
+	IPAMRef *IPAMObjectReference `json:"ipamRef"`
+
+	// Port is the port of the control plane endpoint. Defaults to 6443, or to 8443 with a Keepalived
+	// ControlPlaneLoadBalancer.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+// ControlPlaneLoadBalancerType is the implementation announcing the virtual IP of the control plane endpoint.
+// +kubebuilder:validation:Enum=KubeVIP;Keepalived
+type ControlPlaneLoadBalancerType string
+
+const (
+	// ControlPlaneLoadBalancerKubeVIP announces the virtual IP with a kube-vip static pod. On the machine initializing
+	// the control plane, kube-vip uses the super-admin.conf of kubeadm, as the admin.conf is not authorized before
+	// the control plane is initialized.
+	ControlPlaneLoadBalancerKubeVIP ControlPlaneLoadBalancerType = "KubeVIP"
+	// ControlPlaneLoadBalancerKeepalived announces the virtual IP with keepalived and forwards the traffic to the
+	// local kube-apiserver with haproxy.
+	ControlPlaneLoadBalancerKeepalived ControlPlaneLoadBalancerType = "Keepalived"
+)
+
+// ControlPlaneLoadBalancer describes the static pods announcing the virtual IP of the control plane endpoint.
+type ControlPlaneLoadBalancer struct {
+	// Type is the implementation announcing the virtual IP.
+	Type ControlPlaneLoadBalancerType `json:"type"`
+
+	// Interface is the network interface of the control plane machines the virtual IP is announced on.
+	Interface string `json:"interface"`
+
+	// Image overrides the kube-vip or keepalived image.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// HAProxyImage overrides the haproxy image. It is only used with the Keepalived type.
+	// +optional
+	HAProxyImage string `json:"haproxyImage,omitempty"`
+
+	// APIServerPort is the port of the local kube-apiserver haproxy forwards the traffic to. It is only used with the
+	// Keepalived type and has to differ from the port of the ControlPlaneEndpoint. The default matches the default
+	// bindPort of kubeadm, a different port has to be set as localAPIEndpoint.bindPort of the KubeadmConfig as well.
+	// +kubebuilder:default=6443
+	// +optional
+	APIServerPort int32 `json:"apiServerPort,omitempty"`
+
+	// VirtualRouterID is the VRRP virtual router id of keepalived. It is only used with the Keepalived type.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=255
+	// +kubebuilder:default=51
+	// +optional
+	VirtualRouterID int32 `json:"virtualRouterID,omitempty"`
 }
 
 // IroncoreMetalClusterInitializationStatus provides observations of the IroncoreMetalCluster initialization process.
@@ -45,6 +200,12 @@ type IroncoreMetalClusterStatus struct {
 	// +optional
 	Initialization IroncoreMetalClusterInitializationStatus `json:"initialization,omitempty,omitzero"`
 
+	// FailureDomains is a list of the failure domains the machines of the cluster can be placed into.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	FailureDomains []clusterv1.FailureDomain `json:"failureDomains,omitempty"`
+
 	// Conditions defines current service state of the IroncoreMetalCluster.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
@@ -7,9 +7,12 @@ import (
 	"time"
 
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	capierrors "sigs.k8s.io/cluster-api/errors"
 )
 
 const (
@@ -19,8 +22,32 @@ const (
 
 	// DefaultReconcilerRequeue is the default value for the reconcile retry.
 	DefaultReconcilerRequeue = 5 * time.Second
+
+	// PowerAnnotation requests the power state of the Server of an IroncoreMetalMachine. The value is On or Off, the
+	// Server is powered on if the annotation is not set.
+	PowerAnnotation = "ironcoremetalmachine.infrastructure.cluster.x-k8s.io/power"
+
+	// ServerOperationAnnotation requests a one-shot operation of the Server of an IroncoreMetalMachine, e.g. a reboot.
+	// The value is one of ServerOperations. The annotation is removed once the operation was passed to the Server.
+	ServerOperationAnnotation = "ironcoremetalmachine.infrastructure.cluster.x-k8s.io/server-operation"
+
+	// IgnitionHashAnnotation is set on the ignition secret of an IroncoreMetalMachine to the SHA-256 of the rendered
+	// ignition. It is kept when the ignition is redacted.
+	IgnitionHashAnnotation = "ironcoremetalmachine.infrastructure.cluster.x-k8s.io/ignition-hash"
+
+	// DiskWipeCompletedAnnotation is set on the ServerMaintenance of the ReleaseAfterDiskWipe DeletionPolicy by the disk
+	// wipe once the disks of the Server are sanitised. The ServerMaintenance is removed then and the Server released.
+	DiskWipeCompletedAnnotation = "ironcoremetalmachine.infrastructure.cluster.x-k8s.io/disk-wipe-completed"
 )
 
+// ServerOperations are the supported values of the ServerOperationAnnotation. They are passed to the Server as
+// operation annotation of the metal-operator.
+var ServerOperations = []string{
+	metalv1alpha1.GracefulRestartServerPower,
+	metalv1alpha1.HardRestartServerPower,
+	metalv1alpha1.PowerCycleServerPower,
+}
+
 // IroncoreMetalMachineSpec defines the desired state of IroncoreMetalMachine
 type IroncoreMetalMachineSpec struct {
 	// ProviderID is the unique identifier as specified by the cloud provider.
@@ -43,6 +70,39 @@ type IroncoreMetalMachineSpec struct {
 	// IPAMConfig is a list of references to Network resources that should be used to assign IP addresses to the worker nodes.
 	// +optional
 	IPAMConfig []IPAMConfig `json:"ipamConfig,omitempty"`
+
+	// IgnitionVersion is the Ignition spec version of the ignition passed to the Server. If it is set to a 3.x version,
+	// an Ignition 2.x bootstrap payload is translated to it. Otherwise the version of the bootstrap payload is kept.
+	// +kubebuilder:validation:Pattern=`^3\.[0-9]+\.[0-9]+$`
+	// +optional
+	IgnitionVersion string `json:"ignitionVersion,omitempty"`
+
+	// NetworkConfig describes the interfaces, bonds, VLANs and routes of the machine. It is rendered into systemd-networkd
+	// units or NetworkManager keyfiles which are added to the ignition, the addresses are taken from the IPAMConfig.
+	// +optional
+	NetworkConfig *NetworkConfig `json:"networkConfig,omitempty"`
+
+	// ServerAffinity makes a replacement of the IroncoreMetalMachine re-claim the Server of the machine it replaces.
+	// The bound Server is labelled with the MachineDeployment or control plane of the Machine, and a new
+	// IroncoreMetalMachine of the same owner claims such a Server by name once it is released instead of selecting one.
+	// The Server still has to match the ServerSelector and the failure domain of the new machine. A Server is only
+	// re-claimed if its machine is deleted before the replacement is created, so rollouts have to use
+	// `maxSurge: 0`. With a surge the replacement is provisioned on another Server while the old one is still bound.
+	// +optional
+	ServerAffinity bool `json:"serverAffinity,omitempty"`
+
+	// DeletionPolicy defines what happens to the Server when the IroncoreMetalMachine is deleted.
+	// +kubebuilder:default=Release
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// IgnitionSecretPolicy defines what happens to the ignition secret once the node of the IroncoreMetalMachine has
+	// joined the cluster. The ignition contains the bootstrap token of the node, the Server can not be provisioned
+	// from it again once it has been deleted or redacted.
+	// +kubebuilder:default=Keep
+	// +optional
+	IgnitionSecretPolicy IgnitionSecretPolicy `json:"ignitionSecretPolicy,omitempty"`
+
 	// Metadata is a key-value map of additional data which should be passed to the Machine.
 	// +optional
 	Metadata *apiextensionsv1.JSON `json:"metadata,omitempty"`
@@ -68,6 +128,47 @@ type IroncoreMetalMachineStatus struct {
 	// +optional
 	Initialization IroncoreMetalMachineInitializationStatus `json:"initialization,omitempty,omitzero"`
 
+	// ServerRef is a reference to the Server bound to the ServerClaim of this IroncoreMetalMachine.
+	// It is kept during deletion to wait for the Server to be released.
+	// +optional
+	ServerRef *corev1.LocalObjectReference `json:"serverRef,omitempty"`
+
+	// PowerState is the power state reported by the Server bound to the ServerClaim.
+	// +optional
+	PowerState metalv1alpha1.ServerPowerState `json:"powerState,omitempty"`
+
+	// ServerMaintenanceRef is a reference to the ServerMaintenance requested for the Server by the DeletionPolicy.
+	// +optional
+	ServerMaintenanceRef *corev1.LocalObjectReference `json:"serverMaintenanceRef,omitempty"`
+
+	// Addresses contains the hostname and the IP addresses of the IroncoreMetalMachine. They are allocated from the
+	// IPAM pools of the IPAMConfig or reported by the bound Server.
+	// +optional
+	Addresses []clusterv1.MachineAddress `json:"addresses,omitempty"`
+
+	// IgnitionHash is the SHA-256 of the ignition the Server is provisioned with. A different ignition rendered after
+	// the IroncoreMetalMachine is provisioned is not applied but reported by the Drifted condition.
+	// +optional
+	IgnitionHash string `json:"ignitionHash,omitempty"`
+
+	// ServerClaimHash is the SHA-256 of the image, the ServerSelector and the Tolerations of the ServerClaim. A change
+	// after the IroncoreMetalMachine is provisioned is reported by the Drifted condition.
+	// +optional
+	ServerClaimHash string `json:"serverClaimHash,omitempty"`
+
+	// FailureReason is set when the IroncoreMetalMachine can not be provisioned, e.g. because no Server became
+	// available within the insufficient capacity timeout. Its ServerClaim is deleted and the ProvisioningFailed
+	// condition is set on the Machine, so a MachineHealthCheck can remediate it. It is cleared once a Server becomes
+	// available again.
+	// Deprecated: This field is part of the v1beta1 contract and will be removed in the future.
+	// +optional
+	FailureReason *capierrors.MachineStatusError `json:"failureReason,omitempty"`
+
+	// FailureMessage is a human readable description of the FailureReason.
+	// Deprecated: This field is part of the v1beta1 contract and will be removed in the future.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
 	// Conditions defines current service state of the IroncoreMetalMachine
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
@@ -94,6 +195,16 @@ type IroncoreMetalMachineList struct {
 	Items           []IroncoreMetalMachine `json:"items"`
 }
 
+// GetConditions returns the observations of the operational state of the IroncoreMetalMachine resource.
+func (m *IroncoreMetalMachine) GetConditions() []metav1.Condition {
+	return m.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the IroncoreMetalMachine to the predescribed clusterv1b1.Conditions.
+func (m *IroncoreMetalMachine) SetConditions(conditions []metav1.Condition) {
+	m.Status.Conditions = conditions
+}
+
 func init() {
 	SchemeBuilder.Register(func(s *runtime.Scheme) error {
 		s.AddKnownTypes(SchemeGroupVersion, &IroncoreMetalMachine{}, &IroncoreMetalMachineList{})
@@ -116,5 +227,167 @@ type IPAMConfig struct {
 	// MetadataKey is the name of metadata key for the network.
 	MetadataKey string `json:"metadataKey"`
 	// IPAMRef is a reference to the IPAM object, which will be used for IP allocation.
-	IPAMRef *IPAMObjectReference `json:"ipamRef"`
+	// +optional
+	IPAMRef *IPAMObjectReference `json:"ipamRef,omitempty"`
+	// IPAMRefs are references to further IPAM objects used for IP allocation in the same network, e.g. an IPv6 pool
+	// next to the IPv4 pool of IPAMRef for dual-stack.
+	// +optional
+	IPAMRefs []IPAMObjectReference `json:"ipamRefs,omitempty"`
+	// Count is the number of IP addresses allocated from each of the referenced IPAM objects.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	// +kubebuilder:default=1
+	// +optional
+	Count int32 `json:"count,omitempty"`
+}
+
+// DeletionPolicy defines what happens to the Server of a deleted IroncoreMetalMachine.
+// +kubebuilder:validation:Enum=Release;ReleaseAfterDiskWipe;KeepForInspection
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyRelease releases the Server once it is powered off.
+	DeletionPolicyRelease DeletionPolicy = "Release"
+	// DeletionPolicyReleaseAfterDiskWipe moves the Server into a maintenance to sanitise its disks. The disk wipe has to
+	// set the DiskWipeCompletedAnnotation on the ServerMaintenance once it is done, the ServerMaintenance is removed
+	// then and the Server released. If the wipe does not complete within the disk wipe timeout of the controller, the
+	// ServerReady condition reports DiskWipeTimedOut and the ServerMaintenance is kept, so the Server is not claimed
+	// again until an operator removes it.
+	DeletionPolicyReleaseAfterDiskWipe DeletionPolicy = "ReleaseAfterDiskWipe"
+	// DeletionPolicyKeepForInspection moves the Server into a maintenance which is kept after the IroncoreMetalMachine
+	// is gone, the Server is not claimed again until the ServerMaintenance is removed.
+	DeletionPolicyKeepForInspection DeletionPolicy = "KeepForInspection"
+)
+
+// IgnitionSecretPolicy defines what happens to the ignition secret of an IroncoreMetalMachine whose node has joined
+// the cluster.
+// +kubebuilder:validation:Enum=Keep;Delete;Redact
+type IgnitionSecretPolicy string
+
+const (
+	// IgnitionSecretPolicyKeep keeps the ignition secret until the IroncoreMetalMachine is deleted.
+	IgnitionSecretPolicyKeep IgnitionSecretPolicy = "Keep"
+	// IgnitionSecretPolicyDelete deletes the ignition secret.
+	IgnitionSecretPolicyDelete IgnitionSecretPolicy = "Delete"
+	// IgnitionSecretPolicyRedact removes the ignition from the secret and keeps its IgnitionHashAnnotation.
+	IgnitionSecretPolicyRedact IgnitionSecretPolicy = "Redact"
+)
+
+// NetworkRenderer is the network configuration backend of the operating system of the machine.
+// +kubebuilder:validation:Enum=networkd;NetworkManager
+type NetworkRenderer string
+
+const (
+	// NetworkRendererNetworkd renders systemd-networkd units to /etc/systemd/network.
+	NetworkRendererNetworkd NetworkRenderer = "networkd"
+	// NetworkRendererNetworkManager renders NetworkManager keyfiles to /etc/NetworkManager/system-connections.
+	NetworkRendererNetworkManager NetworkRenderer = "NetworkManager"
+)
+
+// NetworkConfig describes the network configuration of a machine.
+type NetworkConfig struct {
+	// Renderer is the network configuration backend the configuration is rendered for.
+	// +kubebuilder:default=networkd
+	// +optional
+	Renderer NetworkRenderer `json:"renderer,omitempty"`
+
+	// Ethernets are the physical interfaces of the machine.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Ethernets []NetworkEthernet `json:"ethernets,omitempty"`
+
+	// Bonds are the bond interfaces aggregating physical interfaces.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Bonds []NetworkBond `json:"bonds,omitempty"`
+
+	// VLANs are the VLAN interfaces on top of physical or bond interfaces.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	VLANs []NetworkVLAN `json:"vlans,omitempty"`
+}
+
+// NetworkInterface is the configuration shared by all interface types.
+type NetworkInterface struct {
+	// Name is the name of the interface.
+	// +kubebuilder:validation:MaxLength=15
+	Name string `json:"name"`
+
+	// MTU is the maximum transmission unit of the interface.
+	// +kubebuilder:validation:Minimum=576
+	// +kubebuilder:validation:Maximum=9216
+	// +optional
+	MTU int32 `json:"mtu,omitempty"`
+
+	// DHCP enables DHCP for IPv4 and IPv6 on the interface.
+	// +optional
+	DHCP bool `json:"dhcp,omitempty"`
+
+	// AddressesFrom are the MetadataKeys of the IPAMConfig whose allocated addresses are assigned to the interface.
+	// The gateways of the addresses are added as default routes.
+	// +optional
+	AddressesFrom []string `json:"addressesFrom,omitempty"`
+
+	// Routes are additional static routes of the interface.
+	// +optional
+	Routes []NetworkRoute `json:"routes,omitempty"`
+}
+
+// NetworkEthernet is a physical interface.
+type NetworkEthernet struct {
+	NetworkInterface `json:",inline"`
+
+	// MACAddress matches the physical interface by its MAC address instead of its name. The interface is renamed to
+	// Name.
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){5}[0-9a-fA-F]{2}$`
+	// +optional
+	MACAddress string `json:"macAddress,omitempty"`
+}
+
+// NetworkBondMode is the mode of a bond interface.
+// +kubebuilder:validation:Enum=balance-rr;active-backup;balance-xor;broadcast;"802.3ad";balance-tlb;balance-alb
+type NetworkBondMode string
+
+// NetworkBond is a bond interface.
+type NetworkBond struct {
+	NetworkInterface `json:",inline"`
+
+	// Interfaces are the names of the Ethernets aggregated by the bond.
+	// +kubebuilder:validation:MinItems=1
+	Interfaces []string `json:"interfaces"`
+
+	// Mode is the bonding mode.
+	// +kubebuilder:default=active-backup
+	// +optional
+	Mode NetworkBondMode `json:"mode,omitempty"`
+}
+
+// NetworkVLAN is a VLAN interface.
+type NetworkVLAN struct {
+	NetworkInterface `json:",inline"`
+
+	// ID is the VLAN ID.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+	ID int32 `json:"id"`
+
+	// Link is the name of the Ethernet or Bond the VLAN is created on.
+	Link string `json:"link"`
+}
+
+// NetworkRoute is a static route.
+type NetworkRoute struct {
+	// To is the destination of the route in CIDR notation.
+	To string `json:"to"`
+
+	// Via is the gateway of the route.
+	// +optional
+	Via string `json:"via,omitempty"`
+
+	// Metric is the metric of the route.
+	// +optional
+	Metric *int32 `json:"metric,omitempty"`
 }
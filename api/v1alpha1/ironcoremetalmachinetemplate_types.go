@@ -4,6 +4,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -14,14 +15,59 @@ type IroncoreMetalMachineTemplateSpec struct {
 	Template IroncoreMetalMachineTemplateResource `json:"template"`
 }
 
+// IroncoreMetalMachineTemplateStatus defines the observed state of IroncoreMetalMachineTemplate. It is derived from
+// the Servers matching the ServerSelector and the Tolerations of the template and allows the cluster autoscaler to
+// scale from zero.
+type IroncoreMetalMachineTemplateStatus struct {
+	// Capacity defines the resource capacity of a machine created from this template. It is the minimum capacity of
+	// the matching Servers.
+	// This value is used for autoscaling from zero operations as defined in:
+	// https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20210310-opt-in-autoscaling-from-zero.md
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// NodeInfo contains the architecture and the operating system of a machine created from this template.
+	// +optional
+	NodeInfo NodeInfo `json:"nodeInfo,omitempty,omitzero"`
+
+	// AvailableServers is the number of matching Servers which are available to be claimed.
+	// +optional
+	AvailableServers int32 `json:"availableServers,omitempty"`
+}
+
+// Architecture represents the CPU architecture of the node.
+// +kubebuilder:validation:Enum=amd64;arm64;s390x;ppc64le
+type Architecture string
+
+const (
+	ArchitectureAmd64   Architecture = "amd64"
+	ArchitectureArm64   Architecture = "arm64"
+	ArchitectureS390x   Architecture = "s390x"
+	ArchitecturePpc64le Architecture = "ppc64le"
+)
+
+// NodeInfo contains information about the node's architecture and operating system.
+// +kubebuilder:validation:MinProperties=1
+type NodeInfo struct {
+	// Architecture is the CPU architecture of the node.
+	// +optional
+	Architecture Architecture `json:"architecture,omitempty"`
+
+	// OperatingSystem is the operating system of the node, e.g. linux.
+	// +optional
+	OperatingSystem string `json:"operatingSystem,omitempty"`
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 
 // IroncoreMetalMachineTemplate is the Schema for the ironcoremetalmachinetemplates API
 type IroncoreMetalMachineTemplate struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec IroncoreMetalMachineTemplateSpec `json:"spec,omitempty"`
+	Spec   IroncoreMetalMachineTemplateSpec   `json:"spec,omitempty"`
+	Status IroncoreMetalMachineTemplateStatus `json:"status,omitempty"`
 }
 
 // IroncoreMetalMachineTemplateResource defines the spec and metadata for IroncoreMetalMachineTemplate supported by capi.
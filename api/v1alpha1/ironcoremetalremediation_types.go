@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RemediationStrategyType is the type of the remediation of an unhealthy Machine.
+// +kubebuilder:validation:Enum=PowerCycle
+type RemediationStrategyType string
+
+const (
+	// RemediationStrategyPowerCycle power cycles the Server bound to the Machine.
+	RemediationStrategyPowerCycle RemediationStrategyType = "PowerCycle"
+)
+
+// IroncoreMetalRemediationPhase is the phase of an IroncoreMetalRemediation.
+type IroncoreMetalRemediationPhase string
+
+const (
+	// RemediationPhaseRunning is set while the Server is power cycled and the node is given time to recover.
+	RemediationPhaseRunning IroncoreMetalRemediationPhase = "Running"
+	// RemediationPhaseSucceeded is set when the Machine became healthy again after a power cycle.
+	RemediationPhaseSucceeded IroncoreMetalRemediationPhase = "Succeeded"
+	// RemediationPhaseDeleting is set when the retry limit was reached and the Machine is deleted.
+	RemediationPhaseDeleting IroncoreMetalRemediationPhase = "Deleting"
+)
+
+// RemediationStrategy describes how an unhealthy Machine is remediated.
+type RemediationStrategy struct {
+	// Type is the type of the remediation.
+	// +kubebuilder:default=PowerCycle
+	// +optional
+	Type RemediationStrategyType `json:"type,omitempty"`
+
+	// RetryLimit is the maximum number of power cycles before the Machine is deleted.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	RetryLimit int32 `json:"retryLimit,omitempty"`
+
+	// Timeout is the time a node is given to recover after a power cycle before the next attempt.
+	// +kubebuilder:default="5m"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// IroncoreMetalRemediationSpec defines the desired state of IroncoreMetalRemediation
+type IroncoreMetalRemediationSpec struct {
+	// Strategy is the remediation strategy of the unhealthy Machine.
+	// +optional
+	Strategy *RemediationStrategy `json:"strategy,omitempty"`
+}
+
+// IroncoreMetalRemediationStatus defines the observed state of IroncoreMetalRemediation
+type IroncoreMetalRemediationStatus struct {
+	// Phase is the phase of the remediation.
+	// +optional
+	Phase IroncoreMetalRemediationPhase `json:"phase,omitempty"`
+
+	// RetryCount is the number of power cycles requested so far.
+	// +optional
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// LastRemediated is the time the last power cycle was requested.
+	// +optional
+	LastRemediated *metav1.Time `json:"lastRemediated,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// IroncoreMetalRemediation is the Schema for the ironcoremetalremediations API. It is created by a
+// MachineHealthCheck for an unhealthy Machine and carries the name of the Machine.
+type IroncoreMetalRemediation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IroncoreMetalRemediationSpec   `json:"spec,omitempty"`
+	Status IroncoreMetalRemediationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IroncoreMetalRemediationList contains a list of IroncoreMetalRemediation
+type IroncoreMetalRemediationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IroncoreMetalRemediation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(SchemeGroupVersion, &IroncoreMetalRemediation{}, &IroncoreMetalRemediationList{})
+		return nil
+	})
+}
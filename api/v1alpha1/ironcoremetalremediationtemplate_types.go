@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// IroncoreMetalRemediationTemplateSpec defines the desired state of IroncoreMetalRemediationTemplate
+type IroncoreMetalRemediationTemplateSpec struct {
+	Template IroncoreMetalRemediationTemplateResource `json:"template"`
+}
+
+// +kubebuilder:object:root=true
+
+// IroncoreMetalRemediationTemplate is the Schema for the ironcoremetalremediationtemplates API. It is referenced by
+// the remediationTemplate of a MachineHealthCheck.
+type IroncoreMetalRemediationTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IroncoreMetalRemediationTemplateSpec `json:"spec,omitempty"`
+}
+
+// IroncoreMetalRemediationTemplateResource describes the data needed to create an IroncoreMetalRemediation from a
+// template.
+type IroncoreMetalRemediationTemplateResource struct {
+	Spec IroncoreMetalRemediationSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// IroncoreMetalRemediationTemplateList contains a list of IroncoreMetalRemediationTemplate
+type IroncoreMetalRemediationTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IroncoreMetalRemediationTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(SchemeGroupVersion, &IroncoreMetalRemediationTemplate{}, &IroncoreMetalRemediationTemplateList{})
+		return nil
+	})
+}
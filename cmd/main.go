@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util/record"
@@ -27,6 +28,7 @@ import (
 
 	infrastructurev1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
 	"github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/controller"
+	webhookv1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/webhook/v1alpha1"
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
 	capiv1beta1 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
 	// +kubebuilder:scaffold:imports
@@ -52,6 +54,9 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var diskWipeImage string
+	var diskWipeTimeout time.Duration
+	var insufficientCapacityTimeout time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -62,6 +67,18 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&diskWipeImage, "disk-wipe-image", "",
+		"The image Servers are booted into to wipe their disks if the deletion policy of a machine is ReleaseAfterDiskWipe. "+
+			"If empty, the Servers are powered off for an out-of-band wipe. Either way, the wipe has to set the "+
+			"ironcoremetalmachine.infrastructure.cluster.x-k8s.io/disk-wipe-completed annotation on the ServerMaintenance "+
+			"once it is done to release the Server.")
+	flag.DurationVar(&diskWipeTimeout, "disk-wipe-timeout", 24*time.Hour,
+		"The duration after which a disk wipe that has not been marked as completed is given up. The ServerMaintenance "+
+			"is kept to quarantine the Server. If zero, the wipe is awaited indefinitely.")
+	flag.DurationVar(&insufficientCapacityTimeout, "insufficient-capacity-timeout", 0,
+		"The duration after which a machine is marked as failed if no Server is available for its ServerClaim. "+
+			"A failed machine gets the ProvisioningFailed condition, which a MachineHealthCheck can match in its "+
+			"unhealthyMachineConditions to remediate it. If zero, machines wait for a Server indefinitely.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -126,20 +143,59 @@ func main() {
 	// Set up the context that's going to be used in controllers and for the manager.
 	ctx := ctrl.SetupSignalHandler()
 
+	metalClusters := &controller.MetalClusters{}
 	if err = (&controller.IroncoreMetalClusterReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		MetalClusters: metalClusters,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IroncoreMetalCluster")
 		os.Exit(1)
 	}
 	if err = (&controller.IroncoreMetalMachineReconciler{
+		Client:                      mgr.GetClient(),
+		Scheme:                      mgr.GetScheme(),
+		DiskWipeImage:               diskWipeImage,
+		DiskWipeTimeout:             diskWipeTimeout,
+		InsufficientCapacityTimeout: insufficientCapacityTimeout,
+		MetalClusters:               metalClusters,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IroncoreMetalMachine")
+		os.Exit(1)
+	}
+	if err = (&controller.IroncoreMetalMachineTemplateReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		MetalClusters: metalClusters,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IroncoreMetalMachineTemplate")
+		os.Exit(1)
+	}
+	if err = (&controller.IroncoreMetalRemediationReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "IroncoreMetalMachine")
+		setupLog.Error(err, "unable to create controller", "controller", "IroncoreMetalRemediation")
 		os.Exit(1)
 	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookv1alpha1.SetupIroncoreMetalClusterWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "IroncoreMetalCluster")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupIroncoreMetalClusterTemplateWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "IroncoreMetalClusterTemplate")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupIroncoreMetalMachineWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "IroncoreMetalMachine")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupIroncoreMetalMachineTemplateWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "IroncoreMetalMachineTemplate")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"maps"
+	"net/netip"
+	"slices"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	clusterapiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// machineAddresses returns the hostname, the IP addresses allocated via IPAM and the IP addresses reported by the
+// network interfaces of the Server. Server may be nil if the ServerClaim is not bound yet.
+func machineAddresses(hostname string, ipAddressesMetadata map[string]any, server *metalv1alpha1.Server) []clusterapiv1beta2.MachineAddress {
+	addresses := []clusterapiv1beta2.MachineAddress{{
+		Type:    clusterapiv1beta2.MachineHostName,
+		Address: hostname,
+	}}
+	add := func(addr netip.Addr) {
+		if !addr.IsValid() || addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+			return
+		}
+		address := clusterapiv1beta2.MachineAddress{Type: ipAddressType(addr), Address: addr.String()}
+		if !slices.Contains(addresses, address) {
+			addresses = append(addresses, address)
+		}
+	}
+
+	metadataKeys := slices.Sorted(maps.Keys(ipAddressesMetadata))
+	for _, key := range metadataKeys {
+		ipAddress, _ := ipAddressesMetadata[key].(map[string]any)
+		ipAddresses, _ := ipAddress["addresses"].([]any)
+		if len(ipAddresses) == 0 {
+			ipAddresses = []any{ipAddress}
+		}
+		for _, a := range ipAddresses {
+			address, _ := a.(map[string]any)
+			ip, _ := address["ip"].(string)
+			if addr, err := netip.ParseAddr(ip); err == nil {
+				add(addr)
+			}
+		}
+	}
+
+	if server != nil {
+		for _, networkInterface := range server.Status.NetworkInterfaces {
+			for _, ip := range networkInterface.IPs {
+				add(ip.Addr)
+			}
+		}
+	}
+
+	return addresses
+}
+
+// ipAddressType returns InternalIP for private addresses and ExternalIP for all others.
+func ipAddressType(addr netip.Addr) clusterapiv1beta2.MachineAddressType {
+	if addr.IsPrivate() {
+		return clusterapiv1beta2.MachineInternalIP
+	}
+	return clusterapiv1beta2.MachineExternalIP
+}
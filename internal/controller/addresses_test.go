@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	clusterapiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+var _ = Describe("Machine addresses", func() {
+	It("should return the hostname, the IPAM addresses and the addresses of the Server", func() {
+		server := &metalv1alpha1.Server{
+			Status: metalv1alpha1.ServerStatus{
+				NetworkInterfaces: []metalv1alpha1.NetworkInterface{{
+					Name: "eth0",
+					IPs: []metalv1alpha1.IP{
+						{Addr: netip.MustParseAddr("10.0.0.1")},
+						{Addr: netip.MustParseAddr("192.0.2.1")},
+						{Addr: netip.MustParseAddr("fe80::1")},
+					},
+				}, {
+					Name: "eth1",
+					IPs:  []metalv1alpha1.IP{{Addr: netip.MustParseAddr("10.0.0.2")}},
+				}},
+			},
+		}
+		ipAddressesMetadata := map[string]any{
+			"b": map[string]any{"ip": "10.0.0.2"},
+			"a": map[string]any{"ip": "2001:db8::1"},
+		}
+
+		Expect(machineAddresses("machine", ipAddressesMetadata, server)).To(Equal([]clusterapiv1beta2.MachineAddress{
+			{Type: clusterapiv1beta2.MachineHostName, Address: "machine"},
+			{Type: clusterapiv1beta2.MachineExternalIP, Address: "2001:db8::1"},
+			{Type: clusterapiv1beta2.MachineInternalIP, Address: "10.0.0.2"},
+			{Type: clusterapiv1beta2.MachineInternalIP, Address: "10.0.0.1"},
+			{Type: clusterapiv1beta2.MachineExternalIP, Address: "192.0.2.1"},
+		}))
+	})
+
+	It("should return all addresses allocated for a metadata key", func() {
+		ipAddressesMetadata := map[string]any{
+			"a": map[string]any{
+				"ip": "10.0.0.1",
+				"addresses": []any{
+					map[string]any{"ip": "10.0.0.1", "family": "IPv4"},
+					map[string]any{"ip": "2001:db8::1", "family": "IPv6"},
+				},
+			},
+		}
+
+		Expect(machineAddresses("machine", ipAddressesMetadata, nil)).To(Equal([]clusterapiv1beta2.MachineAddress{
+			{Type: clusterapiv1beta2.MachineHostName, Address: "machine"},
+			{Type: clusterapiv1beta2.MachineInternalIP, Address: "10.0.0.1"},
+			{Type: clusterapiv1beta2.MachineExternalIP, Address: "2001:db8::1"},
+		}))
+	})
+
+	It("should only return the hostname without addresses", func() {
+		Expect(machineAddresses("machine", nil, nil)).To(Equal([]clusterapiv1beta2.MachineAddress{
+			{Type: clusterapiv1beta2.MachineHostName, Address: "machine"},
+		}))
+	})
+})
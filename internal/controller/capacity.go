@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/scope"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// matchingServers returns the Servers matching the ServerSelector whose NoBind taints are tolerated. A nil
+// ServerSelector matches all Servers.
+func matchingServers(ctx context.Context, c client.Reader, serverSelector *metav1.LabelSelector, tolerations []metalv1alpha1.Toleration) ([]metalv1alpha1.Server, error) {
+	selector := labels.Everything()
+	if serverSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(serverSelector); err != nil {
+			return nil, fmt.Errorf("invalid ServerSelector: %w", err)
+		}
+	}
+
+	servers := &metalv1alpha1.ServerList{}
+	if err := c.List(ctx, servers, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list Servers: %w", err)
+	}
+
+	var matching []metalv1alpha1.Server
+	for _, server := range servers.Items {
+		if toleratesServerTaints(server.Spec.Taints, tolerations) {
+			matching = append(matching, server)
+		}
+	}
+	return matching, nil
+}
+
+// toleratesServerTaints reports whether the tolerations cover all NoBind taints of a Server, which is how the
+// metal-operator decides whether a ServerClaim can be bound to it.
+func toleratesServerTaints(taints []metalv1alpha1.Taint, tolerations []metalv1alpha1.Toleration) bool {
+	for _, taint := range taints {
+		if taint.Effect != metalv1alpha1.TaintEffectNoBind {
+			continue
+		}
+		tolerated := false
+		for _, toleration := range tolerations {
+			if toleration.Key != taint.Key || (toleration.Effect != "" && toleration.Effect != taint.Effect) {
+				continue
+			}
+			switch toleration.Operator {
+			case metalv1alpha1.TolerationOperatorExists:
+				tolerated = true
+			case metalv1alpha1.TolerationOperatorEqual, "":
+				tolerated = toleration.Value == taint.Value
+			}
+			if tolerated {
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// insufficientCapacity reports whether no unclaimed Server is available for the unbound ServerClaim. A ServerClaim
+// referencing a Server by name waits for that Server to be released and is never short of capacity.
+func (r *IroncoreMetalMachineReconciler) insufficientCapacity(ctx context.Context, machineScope *scope.MachineScope, serverClaim *metalv1alpha1.ServerClaim) (bool, error) {
+	if serverClaim.Spec.ServerRef != nil {
+		return false, nil
+	}
+
+	servers, err := matchingServers(ctx, machineScope.MetalClient, serverClaim.Spec.ServerSelector, serverClaim.Spec.Tolerations)
+	if err != nil {
+		return false, err
+	}
+	for _, server := range servers {
+		if server.Spec.ServerClaimRef == nil && server.Status.State == metalv1alpha1.ServerStateAvailable {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// provisioningFailureRequeue is the interval in which a failed IroncoreMetalMachine checks whether a Server became
+// available again, as the Servers not bound to its ServerClaim are not watched.
+const provisioningFailureRequeue = time.Minute
+
+// reportInsufficientCapacity surfaces that no Server is available for the ServerClaim and marks the
+// IroncoreMetalMachine as failed once the ServerClaim has been waiting longer than the InsufficientCapacityTimeout.
+// Binding the ServerClaim triggers the next reconcile, so it only requeues for the timeout.
+func (r *IroncoreMetalMachineReconciler) reportInsufficientCapacity(ctx context.Context, machineScope *scope.MachineScope, serverClaim *metalv1alpha1.ServerClaim) (ctrl.Result, error) {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+	message := fmt.Sprintf("No unclaimed Server matches the ServerSelector and the Tolerations of ServerClaim %s", serverClaim.Name)
+
+	machineScope.Info("Waiting for a Server to become available", "ServerClaim", serverClaim.Name)
+	if conditions.GetReason(ironcoremetalmachine, infrav1alpha1.ServerClaimBoundCondition) != infrav1alpha1.InsufficientCapacityReason {
+		record.Warn(ironcoremetalmachine, infrav1alpha1.InsufficientCapacityReason, message)
+	}
+	conditions.Set(ironcoremetalmachine, metav1.Condition{
+		Type:    infrav1alpha1.ServerClaimBoundCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  infrav1alpha1.InsufficientCapacityReason,
+		Message: message,
+	})
+	conditions.Set(ironcoremetalmachine, metav1.Condition{
+		Type:    infrav1alpha1.ServerReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  infrav1alpha1.WaitingForServerReason,
+		Message: "Waiting for the ServerClaim to be bound",
+	})
+
+	if r.InsufficientCapacityTimeout <= 0 {
+		return ctrl.Result{}, nil
+	}
+	if remaining := time.Until(serverClaim.CreationTimestamp.Add(r.InsufficientCapacityTimeout)); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	machineScope.Info("Marking the machine as failed, no Server became available", "Timeout", r.InsufficientCapacityTimeout)
+	record.Warnf(ironcoremetalmachine, infrav1alpha1.InsufficientCapacityReason, "No Server became available within %s, marking the machine as failed", r.InsufficientCapacityTimeout)
+	ironcoremetalmachine.Status.FailureReason = ptr.To(capierrors.InsufficientResourcesMachineError)
+	ironcoremetalmachine.Status.FailureMessage = ptr.To(fmt.Sprintf("%s within %s", message, r.InsufficientCapacityTimeout))
+	if err := r.setMachineProvisioningFailedCondition(ctx, machineScope, metav1.Condition{
+		Type:    infrav1alpha1.ProvisioningFailedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  infrav1alpha1.InsufficientCapacityReason,
+		Message: *ironcoremetalmachine.Status.FailureMessage,
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: infrav1alpha1.DefaultReconcilerRequeue}, nil
+}
+
+// reconcileProvisioningFailure stops provisioning a failed IroncoreMetalMachine by deleting its unbound ServerClaim
+// and reports whether it recovered. It recovers if the ServerClaim was bound before it got deleted or a Server
+// matching the ServerSelector and the Tolerations became available, the failure is cleared then.
+func (r *IroncoreMetalMachineReconciler) reconcileProvisioningFailure(ctx context.Context, machineScope *scope.MachineScope, serverSelector *metav1.LabelSelector) (bool, error) {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+
+	serverClaim := &metalv1alpha1.ServerClaim{}
+	if err := getMetalObject(ctx, machineScope, serverClaimKey(machineScope), serverClaim); err == nil {
+		if serverClaim.Spec.ServerRef != nil {
+			machineScope.Info("ServerClaim of the failed machine is bound, clearing the failure", "ServerClaim", serverClaim.Name)
+			return true, r.clearProvisioningFailure(ctx, machineScope)
+		}
+		if serverClaim.DeletionTimestamp.IsZero() {
+			machineScope.Info("Deleting the ServerClaim of the failed machine", "ServerClaim", serverClaim.Name)
+			if err := machineScope.MetalClient.Delete(ctx, serverClaim); client.IgnoreNotFound(err) != nil {
+				return false, fmt.Errorf("failed to delete ServerClaim: %w", err)
+			}
+		}
+		return false, nil
+	} else if !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get ServerClaim: %w", err)
+	}
+
+	servers, err := matchingServers(ctx, machineScope.MetalClient, serverSelector, ironcoremetalmachine.Spec.Tolerations)
+	if err != nil {
+		return false, err
+	}
+	for _, server := range servers {
+		if server.Spec.ServerClaimRef == nil && server.Status.State == metalv1alpha1.ServerStateAvailable {
+			machineScope.Info("A Server became available, clearing the failure", "Server", server.Name)
+			return true, r.clearProvisioningFailure(ctx, machineScope)
+		}
+	}
+	return false, nil
+}
+
+// clearProvisioningFailure removes the failure of a recovered IroncoreMetalMachine and its Machine.
+func (r *IroncoreMetalMachineReconciler) clearProvisioningFailure(ctx context.Context, machineScope *scope.MachineScope) error {
+	machineScope.IroncoreMetalMachine.Status.FailureReason = nil
+	machineScope.IroncoreMetalMachine.Status.FailureMessage = nil
+	return r.setMachineProvisioningFailedCondition(ctx, machineScope, metav1.Condition{
+		Type:   infrav1alpha1.ProvisioningFailedCondition,
+		Status: metav1.ConditionFalse,
+		Reason: infrav1alpha1.ProvisioningRecoveredReason,
+	})
+}
+
+// setMachineProvisioningFailedCondition sets the ProvisioningFailedCondition on the Machine of the
+// IroncoreMetalMachine, which is where a MachineHealthCheck looks for its unhealthyMachineConditions.
+func (r *IroncoreMetalMachineReconciler) setMachineProvisioningFailedCondition(ctx context.Context, machineScope *scope.MachineScope, condition metav1.Condition) error {
+	helper, err := patch.NewHelper(machineScope.Machine, r.Client)
+	if err != nil {
+		return fmt.Errorf("failed to init the patch helper of Machine %s: %w", machineScope.Machine.Name, err)
+	}
+	conditions.Set(machineScope.Machine, condition)
+	if err := helper.Patch(ctx, machineScope.Machine); err != nil {
+		return fmt.Errorf("failed to patch the %s condition of Machine %s: %w", condition.Type, machineScope.Machine.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	bootstrapFormatKey = "format"
+	cloudConfigFormat  = "cloud-config"
+	cloudConfigHeader  = "#cloud-config"
+)
+
+// createCloudConfig adds the files and the hostname of the machine to the cloud-config bootstrap data.
+func createCloudConfig(hostname string, data []byte, files []staticFile) ([]byte, error) {
+	// keep the leading comments, e.g. "## template: jinja" and "#cloud-config", as cloud-init relies on them
+	var header []string
+	lines := strings.Split(string(data), "\n")
+	for len(lines) > 0 && strings.HasPrefix(lines[0], "#") {
+		header = append(header, lines[0])
+		lines = lines[1:]
+	}
+	if len(header) == 0 {
+		header = append(header, cloudConfigHeader)
+	}
+
+	cloudConfig := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &cloudConfig); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cloud-config: %w", err)
+	}
+
+	if _, ok := cloudConfig["hostname"]; !ok {
+		cloudConfig["hostname"] = hostname
+	}
+
+	if len(files) > 0 {
+		writeFiles, _ := cloudConfig["write_files"].([]any)
+		for _, file := range files {
+			writeFiles = append(writeFiles, map[string]any{
+				"path":        file.Path,
+				"permissions": fmt.Sprintf("%04o", file.Mode),
+				"encoding":    "b64",
+				"content":     base64.StdEncoding.EncodeToString(file.Data),
+			})
+		}
+		cloudConfig["write_files"] = writeFiles
+	}
+
+	out, err := yaml.Marshal(cloudConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cloud-config: %w", err)
+	}
+	return append([]byte(strings.Join(header, "\n")+"\n"), out...), nil
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/scope"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	diskWipeMaintenanceReason   = "disk-wipe"
+	inspectionMaintenanceReason = "inspection"
+)
+
+// ensureServerMaintenance requests the ServerMaintenance of the DeletionPolicy for the Server bound to the ServerClaim
+// and reports whether the Server can be released. The Server is kept for inspection once it is in maintenance. A disk
+// wipe is finished once the DiskWipeCompletedAnnotation is set on its ServerMaintenance, which is removed then. If the
+// wipe does not complete within the DiskWipeTimeout, the ServerMaintenance is kept to quarantine the Server.
+func (r *IroncoreMetalMachineReconciler) ensureServerMaintenance(ctx context.Context, machineScope *scope.MachineScope) (bool, error) {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+	policy := ironcoremetalmachine.Spec.DeletionPolicy
+	if policy != infrav1alpha1.DeletionPolicyReleaseAfterDiskWipe && policy != infrav1alpha1.DeletionPolicyKeepForInspection {
+		return true, nil
+	}
+
+	if ref := ironcoremetalmachine.Status.ServerMaintenanceRef; ref != nil {
+		maintenance := &metalv1alpha1.ServerMaintenance{}
+		if err := machineScope.MetalClient.Get(ctx, client.ObjectKey{Namespace: machineScope.MetalNamespace, Name: ref.Name}, maintenance); err != nil {
+			return apierrors.IsNotFound(err), client.IgnoreNotFound(err)
+		}
+		if policy == infrav1alpha1.DeletionPolicyKeepForInspection {
+			return maintenance.Status.State == metalv1alpha1.ServerMaintenanceStateInMaintenance, nil
+		}
+		return r.ensureDiskWipeCompleted(ctx, machineScope, maintenance)
+	}
+
+	serverClaim := &metalv1alpha1.ServerClaim{}
+	if err := getMetalObject(ctx, machineScope, serverClaimKey(machineScope), serverClaim); err != nil {
+		return apierrors.IsNotFound(err), client.IgnoreNotFound(err)
+	}
+	if serverClaim.Spec.ServerRef == nil {
+		// the ServerClaim was never bound, there is no Server to take care of
+		return true, nil
+	}
+
+	maintenance := r.serverMaintenance(machineScope, serverClaim.Spec.ServerRef.Name)
+	machineScope.Info("Creating ServerMaintenance", "ServerMaintenance", maintenance.Name, "DeletionPolicy", policy)
+	if err := machineScope.MetalClient.Create(ctx, maintenance); err != nil && !apierrors.IsAlreadyExists(err) {
+		return false, fmt.Errorf("failed to create ServerMaintenance: %w", err)
+	}
+	ironcoremetalmachine.Status.ServerMaintenanceRef = &corev1.LocalObjectReference{Name: maintenance.Name}
+	return false, nil
+}
+
+// ensureDiskWipeCompleted removes the disk wipe ServerMaintenance once the DiskWipeCompletedAnnotation has been set on
+// it and reports whether the Server can be released. A wipe exceeding the DiskWipeTimeout is reported as failed and
+// its ServerMaintenance is kept, so the Server with the unwiped disks is not claimed again.
+func (r *IroncoreMetalMachineReconciler) ensureDiskWipeCompleted(ctx context.Context, machineScope *scope.MachineScope, maintenance *metalv1alpha1.ServerMaintenance) (bool, error) {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+
+	if _, ok := maintenance.Annotations[infrav1alpha1.DiskWipeCompletedAnnotation]; ok {
+		if maintenance.DeletionTimestamp.IsZero() {
+			machineScope.Info("Deleting ServerMaintenance of the completed disk wipe", "ServerMaintenance", maintenance.Name)
+			if err := machineScope.MetalClient.Delete(ctx, maintenance); client.IgnoreNotFound(err) != nil {
+				return false, fmt.Errorf("failed to delete ServerMaintenance: %w", err)
+			}
+		}
+		return false, nil
+	}
+
+	if r.DiskWipeTimeout <= 0 || time.Since(maintenance.CreationTimestamp.Time) < r.DiskWipeTimeout {
+		return false, nil
+	}
+
+	message := fmt.Sprintf("The disks of the Server were not wiped within %s, ServerMaintenance %s is kept", r.DiskWipeTimeout, maintenance.Name)
+	if conditions.GetReason(ironcoremetalmachine, infrav1alpha1.ServerReadyCondition) != infrav1alpha1.DiskWipeTimedOutReason {
+		machineScope.Info("Disk wipe timed out, keeping the Server in maintenance", "ServerMaintenance", maintenance.Name, "Timeout", r.DiskWipeTimeout)
+		record.Warn(ironcoremetalmachine, infrav1alpha1.DiskWipeTimedOutReason, message)
+	}
+	conditions.Set(ironcoremetalmachine, metav1.Condition{
+		Type:    infrav1alpha1.ServerReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  infrav1alpha1.DiskWipeTimedOutReason,
+		Message: message,
+	})
+	return true, nil
+}
+
+// serverMaintenance returns the ServerMaintenance of the DeletionPolicy of the IroncoreMetalMachine in the namespace of
+// its ServerClaim. It is not owned by the IroncoreMetalMachine, so it outlives its deletion. If a disk wipe image is
+// configured, the Server is booted into it, otherwise it is powered off for an out-of-band wipe.
+func (r *IroncoreMetalMachineReconciler) serverMaintenance(machineScope *scope.MachineScope, serverName string) *metalv1alpha1.ServerMaintenance {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+	reason := inspectionMaintenanceReason
+	if ironcoremetalmachine.Spec.DeletionPolicy == infrav1alpha1.DeletionPolicyReleaseAfterDiskWipe {
+		reason = diskWipeMaintenanceReason
+	}
+
+	maintenance := &metalv1alpha1.ServerMaintenance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      truncateWithHash(fmt.Sprintf("%s-%s", metalObjectName(machineScope, ""), reason), validation.DNS1123SubdomainMaxLength),
+			Namespace: machineScope.MetalNamespace,
+			Labels:    metalObjectLabels(ironcoremetalmachine),
+			Annotations: map[string]string{
+				metalv1alpha1.ServerMaintenanceReasonAnnotationKey: reason,
+			},
+		},
+		Spec: metalv1alpha1.ServerMaintenanceSpec{
+			Policy:    metalv1alpha1.ServerMaintenancePolicyEnforced,
+			ServerRef: &corev1.LocalObjectReference{Name: serverName},
+		},
+	}
+
+	if reason == diskWipeMaintenanceReason {
+		maintenance.Spec.ServerPower = metalv1alpha1.PowerOff
+		if r.DiskWipeImage != "" {
+			maintenance.Spec.ServerPower = metalv1alpha1.PowerOn
+			maintenance.Spec.ServerBootConfigurationTemplate = &metalv1alpha1.ServerBootConfigurationTemplate{
+				Name: maintenance.Name,
+				Spec: metalv1alpha1.ServerBootConfigurationSpec{
+					ServerRef: corev1.LocalObjectReference{Name: serverName},
+					Image:     r.DiskWipeImage,
+				},
+			}
+		}
+	}
+	return maintenance
+}
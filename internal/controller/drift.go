@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/scope"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+)
+
+// ignitionDrifted reports whether the rendered ignition differs from the one the IroncoreMetalMachine was
+// provisioned with. The ignition of an IroncoreMetalMachine provisioned before the IgnitionHash was recorded is adopted.
+func ignitionDrifted(ironcoremetalmachine *infrav1alpha1.IroncoreMetalMachine, ignition []byte) bool {
+	return ptr.Deref(ironcoremetalmachine.Status.Initialization.Provisioned, false) &&
+		ironcoremetalmachine.Status.IgnitionHash != "" &&
+		ironcoremetalmachine.Status.IgnitionHash != ignitionHash(ignition)
+}
+
+// serverClaimHash returns the value of the ServerClaimHash of the parts of the ServerClaim which are only set on its
+// creation.
+func serverClaimHash(ironcoremetalmachine *infrav1alpha1.IroncoreMetalMachine, serverSelector *metav1.LabelSelector) (string, error) {
+	data, err := json.Marshal(metalv1alpha1.ServerClaimSpec{
+		Image:          ironcoremetalmachine.Spec.Image,
+		ServerSelector: serverSelector,
+		Tolerations:    ironcoremetalmachine.Spec.Tolerations,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the ServerClaim spec: %w", err)
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// reconcileDrift records the ServerClaimHash until the IroncoreMetalMachine is provisioned and reports a drift of the
+// ignition or the ServerClaim afterwards by the Drifted condition and a Warning event.
+func reconcileDrift(machineScope *scope.MachineScope, ignitionDrifted bool, serverSelector *metav1.LabelSelector) error {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+	hash, err := serverClaimHash(ironcoremetalmachine, serverSelector)
+	if err != nil {
+		return err
+	}
+
+	var drifted []string
+	reason := infrav1alpha1.NotDriftedReason
+	switch {
+	case !ptr.Deref(ironcoremetalmachine.Status.Initialization.Provisioned, false) || ironcoremetalmachine.Status.ServerClaimHash == "":
+		ironcoremetalmachine.Status.ServerClaimHash = hash
+	case ironcoremetalmachine.Status.ServerClaimHash != hash:
+		drifted = append(drifted, "ServerClaim")
+		reason = infrav1alpha1.ServerClaimDriftedReason
+	}
+	if ignitionDrifted {
+		drifted = append([]string{"ignition"}, drifted...)
+		reason = infrav1alpha1.IgnitionDriftedReason
+	}
+
+	if len(drifted) == 0 {
+		conditions.Set(ironcoremetalmachine, metav1.Condition{
+			Type:   infrav1alpha1.DriftedCondition,
+			Status: metav1.ConditionFalse,
+			Reason: reason,
+		})
+		return nil
+	}
+
+	message := fmt.Sprintf("The %s changed after the machine was provisioned, replace the machine to apply the change", strings.Join(drifted, " and "))
+	if !conditions.IsTrue(ironcoremetalmachine, infrav1alpha1.DriftedCondition) {
+		machineScope.Info("Detected a drift of the provisioned machine", "Drifted", drifted)
+		record.Warn(ironcoremetalmachine, infrav1alpha1.DriftedCondition, message)
+	}
+	conditions.Set(ironcoremetalmachine, metav1.Condition{
+		Type:    infrav1alpha1.DriftedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	return nil
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/scope"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterapiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// failureDomainsStatus returns the failure domains of the IroncoreMetalCluster as reported in its status.
+func failureDomainsStatus(metalCluster *infrav1alpha1.IroncoreMetalCluster) []clusterapiv1beta2.FailureDomain {
+	if len(metalCluster.Spec.FailureDomains) == 0 {
+		return nil
+	}
+
+	failureDomains := make([]clusterapiv1beta2.FailureDomain, 0, len(metalCluster.Spec.FailureDomains))
+	for _, failureDomain := range metalCluster.Spec.FailureDomains {
+		failureDomains = append(failureDomains, clusterapiv1beta2.FailureDomain{
+			Name:         failureDomain.Name,
+			ControlPlane: ptr.To(failureDomain.ControlPlane),
+		})
+	}
+	return failureDomains
+}
+
+// serverSelectorForMachine returns the ServerSelector of the IroncoreMetalMachine combined with the ServerSelector
+// of the failure domain of the Machine.
+func serverSelectorForMachine(machineScope *scope.MachineScope) (*metav1.LabelSelector, error) {
+	serverSelector := machineScope.IroncoreMetalMachine.Spec.ServerSelector
+	name := machineScope.Machine.Spec.FailureDomain
+	if name == "" {
+		return serverSelector, nil
+	}
+
+	for _, failureDomain := range machineScope.IroncoreMetalCluster.Spec.FailureDomains {
+		if failureDomain.Name == name {
+			return mergeLabelSelectors(serverSelector, &failureDomain.ServerSelector)
+		}
+	}
+	return nil, fmt.Errorf("failure domain %q is not defined in IroncoreMetalCluster %s", name, machineScope.IroncoreMetalCluster.Name)
+}
+
+// mergeLabelSelectors returns a label selector which only matches objects matched by both label selectors.
+func mergeLabelSelectors(a, b *metav1.LabelSelector) (*metav1.LabelSelector, error) {
+	if a == nil {
+		return b.DeepCopy(), nil
+	}
+	if b == nil {
+		return a.DeepCopy(), nil
+	}
+
+	merged := a.DeepCopy()
+	for key, value := range b.MatchLabels {
+		if existing, ok := merged.MatchLabels[key]; ok && existing != value {
+			return nil, fmt.Errorf("label %s is required to be both %q and %q", key, existing, value)
+		}
+		if merged.MatchLabels == nil {
+			merged.MatchLabels = make(map[string]string)
+		}
+		merged.MatchLabels[key] = value
+	}
+	for _, expression := range b.MatchExpressions {
+		merged.MatchExpressions = append(merged.MatchExpressions, *expression.DeepCopy())
+	}
+	return merged, nil
+}
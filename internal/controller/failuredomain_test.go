@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Failure domains", func() {
+	It("should keep a label selector if the other one is not set", func() {
+		selector := &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "1"}}
+
+		Expect(mergeLabelSelectors(nil, selector)).To(Equal(selector))
+		Expect(mergeLabelSelectors(selector, nil)).To(Equal(selector))
+	})
+
+	It("should reject label selectors requiring different values for a label", func() {
+		_, err := mergeLabelSelectors(
+			&metav1.LabelSelector{MatchLabels: map[string]string{"rack": "1"}},
+			&metav1.LabelSelector{MatchLabels: map[string]string{"rack": "2"}},
+		)
+		Expect(err).To(MatchError(`label rack is required to be both "1" and "2"`))
+	})
+})
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const systemdNetworkDir = "/etc/systemd/network"
+
+// ignitionVersion returns the spec version of the ignition.
+func ignitionVersion(ignitionMap map[string]any) string {
+	ignition, _ := ignitionMap["ignition"].(map[string]any)
+	version, _ := ignition["version"].(string)
+	return version
+}
+
+// isIgnitionV3 returns true if the ignition spec version is 3.x.
+func isIgnitionV3(version string) bool {
+	return strings.HasPrefix(version, "3.")
+}
+
+// ignitionFile returns the ignition storage entry of a file for the ignition spec version.
+func ignitionFile(file staticFile, version string) map[string]any {
+	entry := map[string]any{
+		"path": file.Path,
+		"mode": file.Mode,
+		"contents": map[string]any{
+			"compression": "",
+			"source":      "data:;base64," + base64.StdEncoding.EncodeToString(file.Data),
+		},
+	}
+	if isIgnitionV3(version) {
+		entry["overwrite"] = true
+	} else {
+		entry["filesystem"] = fileSystem
+	}
+	return entry
+}
+
+// translateIgnitionV2ToV3 translates an Ignition 2.x config in place to the given 3.x spec version. The semantics of
+// 2.x are kept, e.g. files are overwritten. Configs using 2.x features without a 3.x equivalent are rejected.
+func translateIgnitionV2ToV3(ignitionMap map[string]any, version string) error {
+	if !isIgnitionV3(version) {
+		return fmt.Errorf("ignition version %q is not a 3.x version", version)
+	}
+	if current := ignitionVersion(ignitionMap); !strings.HasPrefix(current, "2.") {
+		return fmt.Errorf("ignition version %q is not a 2.x version", current)
+	}
+
+	ignition, _ := ignitionMap["ignition"].(map[string]any)
+	ignition["version"] = version
+	if config, ok := ignition["config"].(map[string]any); ok {
+		if appendConfigs, ok := config["append"]; ok {
+			config["merge"] = appendConfigs
+			delete(config, "append")
+		}
+	}
+
+	storage, _ := ignitionMap["storage"].(map[string]any)
+	if err := translateIgnitionV2Filesystems(storage); err != nil {
+		return err
+	}
+	for _, key := range []string{"files", "directories", "links"} {
+		if err := translateIgnitionV2Nodes(storage, key); err != nil {
+			return err
+		}
+	}
+
+	if networkd, ok := ignitionMap["networkd"].(map[string]any); ok {
+		units, _ := networkd["units"].([]any)
+		for _, u := range units {
+			unit, _ := u.(map[string]any)
+			name, _ := unit["name"].(string)
+			contents, _ := unit["contents"].(string)
+			if storage == nil {
+				storage = make(map[string]any)
+				ignitionMap["storage"] = storage
+			}
+			files, _ := storage["files"].([]any)
+			storage["files"] = append(files, ignitionFile(staticFile{
+				Path: path.Join(systemdNetworkDir, name),
+				Mode: fileMode,
+				Data: []byte(contents),
+			}, version))
+		}
+		delete(ignitionMap, "networkd")
+	}
+
+	if systemd, ok := ignitionMap["systemd"].(map[string]any); ok {
+		units, _ := systemd["units"].([]any)
+		for _, u := range units {
+			unit, _ := u.(map[string]any)
+			if enable, ok := unit["enable"]; ok {
+				if _, ok := unit["enabled"]; !ok {
+					unit["enabled"] = enable
+				}
+				delete(unit, "enable")
+			}
+		}
+	}
+
+	if passwd, ok := ignitionMap["passwd"].(map[string]any); ok {
+		users, _ := passwd["users"].([]any)
+		for _, u := range users {
+			user, _ := u.(map[string]any)
+			if _, ok := user["create"]; ok {
+				return fmt.Errorf("user %v uses the create field which is not supported by ignition 3.x", user["name"])
+			}
+		}
+	}
+
+	return nil
+}
+
+// translateIgnitionV2Nodes removes the filesystem field of the files, directories or links and keeps the 2.x
+// overwrite and append semantics of files.
+func translateIgnitionV2Nodes(storage map[string]any, key string) error {
+	nodes, _ := storage[key].([]any)
+	for _, n := range nodes {
+		node, _ := n.(map[string]any)
+		if filesystem, ok := node["filesystem"]; ok && filesystem != fileSystem {
+			return fmt.Errorf("%s entry %v uses filesystem %v, only %q is supported", key, node["path"], filesystem, fileSystem)
+		}
+		delete(node, "filesystem")
+
+		if key != "files" {
+			continue
+		}
+		if appendFile, _ := node["append"].(bool); appendFile {
+			if contents, ok := node["contents"]; ok {
+				node["append"] = []any{contents}
+				delete(node, "contents")
+			} else {
+				delete(node, "append")
+			}
+			continue
+		}
+		delete(node, "append")
+		node["overwrite"] = true
+	}
+	return nil
+}
+
+// translateIgnitionV2Filesystems moves the mount options of the filesystems to the filesystem itself as 3.x expects.
+// The root filesystem is dropped as 3.x refers to it implicitly.
+func translateIgnitionV2Filesystems(storage map[string]any) error {
+	filesystems, _ := storage["filesystems"].([]any)
+	if filesystems == nil {
+		return nil
+	}
+
+	translated := make([]any, 0, len(filesystems))
+	for _, f := range filesystems {
+		filesystem, _ := f.(map[string]any)
+		if filesystem["name"] == fileSystem {
+			continue
+		}
+		mount, _ := filesystem["mount"].(map[string]any)
+		if mount == nil {
+			return fmt.Errorf("filesystem %v has no mount which is not supported by ignition 3.x", filesystem["name"])
+		}
+		if _, ok := mount["create"]; ok {
+			return fmt.Errorf("filesystem %v uses the create field which is not supported by ignition 3.x", filesystem["name"])
+		}
+		for k, v := range mount {
+			filesystem[k] = v
+		}
+		delete(filesystem, "mount")
+		delete(filesystem, "name")
+		translated = append(translated, filesystem)
+	}
+	storage["filesystems"] = translated
+	return nil
+}
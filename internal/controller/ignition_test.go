@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ignition", func() {
+	unmarshal := func(data string) map[string]any {
+		ignitionMap := make(map[string]any)
+		Expect(json.Unmarshal([]byte(data), &ignitionMap)).To(Succeed())
+		return ignitionMap
+	}
+
+	It("should render files for the ignition version", func() {
+		file := staticFile{Path: "/etc/foo", Mode: fileMode, Data: []byte("foo")}
+
+		Expect(ignitionFile(file, "2.3.0")).To(HaveKeyWithValue("filesystem", fileSystem))
+		Expect(ignitionFile(file, "2.3.0")).NotTo(HaveKey("overwrite"))
+		Expect(ignitionFile(file, "3.4.0")).To(HaveKeyWithValue("overwrite", true))
+		Expect(ignitionFile(file, "3.4.0")).NotTo(HaveKey("filesystem"))
+	})
+
+	It("should translate an Ignition 2.x config to 3.x", func() {
+		ignitionMap := unmarshal(`{
+			"ignition": {"version": "2.3.0", "config": {"append": [{"source": "http://foo"}]}},
+			"storage": {
+				"files": [
+					{"filesystem": "root", "path": "/etc/foo", "mode": 420, "contents": {"source": "data:,foo"}},
+					{"filesystem": "root", "path": "/etc/bar", "append": true, "contents": {"source": "data:,bar"}}
+				],
+				"directories": [{"filesystem": "root", "path": "/etc/baz"}],
+				"filesystems": [
+					{"name": "root"},
+					{"name": "data", "mount": {"device": "/dev/sdb", "format": "xfs"}}
+				]
+			},
+			"networkd": {"units": [{"name": "10-eth0.network", "contents": "[Match]"}]},
+			"systemd": {"units": [{"name": "foo.service", "enable": true}]}
+		}`)
+
+		Expect(translateIgnitionV2ToV3(ignitionMap, "3.4.0")).To(Succeed())
+
+		Expect(ignitionVersion(ignitionMap)).To(Equal("3.4.0"))
+		Expect(ignitionMap).NotTo(HaveKey("networkd"))
+		Expect(ignitionMap["ignition"]).To(HaveKeyWithValue("config", HaveKey("merge")))
+		Expect(ignitionMap["systemd"]).To(HaveKeyWithValue("units", ConsistOf(
+			map[string]any{"name": "foo.service", "enabled": true},
+		)))
+
+		storage := ignitionMap["storage"].(map[string]any)
+		Expect(storage["filesystems"]).To(ConsistOf(
+			map[string]any{"device": "/dev/sdb", "format": "xfs"},
+		))
+		Expect(storage["directories"]).To(ConsistOf(
+			map[string]any{"path": "/etc/baz"},
+		))
+		Expect(storage["files"]).To(ConsistOf(
+			map[string]any{"path": "/etc/foo", "mode": float64(420), "overwrite": true, "contents": map[string]any{"source": "data:,foo"}},
+			map[string]any{"path": "/etc/bar", "append": []any{map[string]any{"source": "data:,bar"}}},
+			map[string]any{
+				"path":      "/etc/systemd/network/10-eth0.network",
+				"mode":      fileMode,
+				"overwrite": true,
+				"contents": map[string]any{
+					"compression": "",
+					"source":      "data:;base64," + base64.StdEncoding.EncodeToString([]byte("[Match]")),
+				},
+			},
+		))
+	})
+
+	It("should reject files on other filesystems than root", func() {
+		ignitionMap := unmarshal(`{
+			"ignition": {"version": "2.3.0"},
+			"storage": {"files": [{"filesystem": "data", "path": "/foo"}]}
+		}`)
+
+		Expect(translateIgnitionV2ToV3(ignitionMap, "3.4.0")).To(MatchError(ContainSubstring(`only "root" is supported`)))
+	})
+
+	It("should reject users which are created with the create field", func() {
+		ignitionMap := unmarshal(`{
+			"ignition": {"version": "2.3.0"},
+			"passwd": {"users": [{"name": "core", "create": {}}]}
+		}`)
+
+		Expect(translateIgnitionV2ToV3(ignitionMap, "3.4.0")).To(MatchError(ContainSubstring("create field")))
+	})
+})
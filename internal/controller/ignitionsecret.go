@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/scope"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ignitionSecretName returns the name of the ignition secret of the IroncoreMetalMachine.
+func ignitionSecretName(machineScope *scope.MachineScope) string {
+	return metalObjectName(machineScope, "ignition-")
+}
+
+// ignitionHash returns the value of the IgnitionHashAnnotation of the ignition.
+func ignitionHash(ignition []byte) string {
+	hash := sha256.Sum256(ignition)
+	return hex.EncodeToString(hash[:])
+}
+
+// ignitionSecretRetired reports whether the IgnitionSecretPolicy requests to delete or redact the ignition secret,
+// which is the case once the node of the Machine has joined the cluster and consumed its bootstrap token.
+func ignitionSecretRetired(machineScope *scope.MachineScope) bool {
+	policy := machineScope.IroncoreMetalMachine.Spec.IgnitionSecretPolicy
+	if policy != infrav1alpha1.IgnitionSecretPolicyDelete && policy != infrav1alpha1.IgnitionSecretPolicyRedact {
+		return false
+	}
+	return machineScope.Machine.Status.NodeRef.IsDefined()
+}
+
+// retireIgnitionSecret deletes or redacts the ignition secret according to the IgnitionSecretPolicy and returns the
+// reason of the IgnitionReady condition.
+func (r *IroncoreMetalMachineReconciler) retireIgnitionSecret(ctx context.Context, machineScope *scope.MachineScope) (string, error) {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: machineScope.MetalNamespace, Name: ignitionSecretName(machineScope)}
+	reason := infrav1alpha1.IgnitionRedactedReason
+	if ironcoremetalmachine.Spec.IgnitionSecretPolicy == infrav1alpha1.IgnitionSecretPolicyDelete {
+		reason = infrav1alpha1.IgnitionDeletedReason
+	}
+	if err := getMetalObject(ctx, machineScope, secretKey, secret); err != nil {
+		return reason, client.IgnoreNotFound(err)
+	}
+
+	if ironcoremetalmachine.Spec.IgnitionSecretPolicy == infrav1alpha1.IgnitionSecretPolicyDelete {
+		if err := machineScope.MetalClient.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return "", fmt.Errorf("failed to delete the ignition secret: %w", err)
+		}
+		machineScope.Info("Deleted the ignition secret, the node has joined the cluster", "Secret", secret.Name)
+		return reason, nil
+	}
+
+	if len(secret.Data) == 0 {
+		return infrav1alpha1.IgnitionRedactedReason, nil
+	}
+	secretBase := secret.DeepCopy()
+	secret.Data = nil
+	if err := machineScope.MetalClient.Patch(ctx, secret, client.MergeFrom(secretBase)); err != nil {
+		return "", fmt.Errorf("failed to redact the ignition secret: %w", err)
+	}
+	machineScope.Info("Redacted the ignition secret, the node has joined the cluster", "Secret", secret.Name)
+	return infrav1alpha1.IgnitionRedactedReason, nil
+}
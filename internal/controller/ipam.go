@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// nameHashLength is the length of the hash suffix of truncated names.
+const nameHashLength = 8
+
+// truncateWithHash returns name if it does not exceed maxLength. Otherwise name is truncated and suffixed with a hash
+// of the full name, so that different long names do not collide.
+func truncateWithHash(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	return strings.TrimRight(name[:maxLength-nameHashLength-1], "-._") + "-" + nameHash(name)
+}
+
+// nameHash returns a short hash of name to be used in object names.
+func nameHash(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])[:nameHashLength]
+}
+
+// ipAddressClaimName returns the name of the IPAddressClaim of an IroncoreMetalMachine for the address with the given
+// index of a MetadataKey. The name is suffixed with a hash of all three, as joining the machine name and the
+// MetadataKey alone is ambiguous if either contains a dash.
+func ipAddressClaimName(machineName, metadataKey string, index int) string {
+	hash := nameHash(fmt.Sprintf("%s/%s/%d", machineName, metadataKey, index))
+	return truncateWithHash(fmt.Sprintf("%s-%s-%s", machineName, metadataKey, hash), validation.DNS1123SubdomainMaxLength)
+}
+
+// legacyIPAddressClaimName returns the name of the IPAddressClaims created before the names were suffixed with a hash
+// for every address, so they can still be adopted.
+func legacyIPAddressClaimName(machineName, metadataKey string, index int) string {
+	name := fmt.Sprintf("%s-%s", machineName, metadataKey)
+	if index > 0 {
+		name = fmt.Sprintf("%s-%s", name, nameHash(fmt.Sprintf("%s/%s/%d", machineName, metadataKey, index)))
+	}
+	return truncateWithHash(name, validation.DNS1123SubdomainMaxLength)
+}
+
+// ipAddressClaimLabels returns the labels identifying the IPAddressClaim of an IroncoreMetalMachine for the address
+// with the given index of a MetadataKey.
+func ipAddressClaimLabels(metalMachine *infrav1alpha1.IroncoreMetalMachine, metadataKey string, index int) map[string]string {
+	return map[string]string{
+		LabelKeyServerClaimName:      truncateWithHash(metalMachine.Name, validation.LabelValueMaxLength),
+		LabelKeyServerClaimNamespace: metalMachine.Namespace,
+		LabelKeyIPAMMetadataKey:      truncateWithHash(metadataKey, validation.LabelValueMaxLength),
+		LabelKeyIPAMIndex:            strconv.Itoa(index),
+	}
+}
+
+// ipamConfigPools returns the IPAM pool of each address requested by the IPAMConfig. Count addresses are requested
+// from IPAMRef and each of the IPAMRefs.
+func ipamConfigPools(ipamConfig infrav1alpha1.IPAMConfig) []*infrav1alpha1.IPAMObjectReference {
+	var refs []*infrav1alpha1.IPAMObjectReference
+	if ipamConfig.IPAMRef != nil || len(ipamConfig.IPAMRefs) == 0 {
+		refs = append(refs, ipamConfig.IPAMRef)
+	}
+	for i := range ipamConfig.IPAMRefs {
+		refs = append(refs, &ipamConfig.IPAMRefs[i])
+	}
+
+	count := max(int(ipamConfig.Count), 1)
+	pools := make([]*infrav1alpha1.IPAMObjectReference, 0, len(refs)*count)
+	for _, ref := range refs {
+		for range count {
+			pools = append(pools, ref)
+		}
+	}
+	return pools
+}
+
+// ipAddressMetadata returns the metadata of an allocated IPAddress.
+func ipAddressMetadata(ipAddr *capiv1beta2.IPAddress) map[string]any {
+	family := "IPv6"
+	if addr, err := netip.ParseAddr(ipAddr.Spec.Address); err == nil && addr.Is4() {
+		family = "IPv4"
+	}
+	return map[string]any{
+		"ip":      ipAddr.Spec.Address,
+		"prefix":  ipAddr.Spec.Prefix,
+		"gateway": ipAddr.Spec.Gateway,
+		"family":  family,
+	}
+}
+
+// newIPAddressClaim returns an IPAddressClaim requesting an address from the pool referenced by ipamRef.
+func newIPAddressClaim(key client.ObjectKey, labels map[string]string, ipamRef *infrav1alpha1.IPAMObjectReference) (*capiv1beta2.IPAddressClaim, error) {
+	if ipamRef == nil {
+		return nil, errors.New("ipamRef of an ipamConfig is not set")
+	}
+	return &capiv1beta2.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels:    labels,
+		},
+		Spec: capiv1beta2.IPAddressClaimSpec{
+			PoolRef: capiv1beta2.IPPoolReference{
+				APIGroup: ipamRef.APIGroup,
+				Kind:     ipamRef.Kind,
+				Name:     ipamRef.Name,
+			},
+		},
+	}, nil
+}
+
+// getOrCreateIPAddressClaim returns the IPAddressClaim with the given key and creates it, controlled by owner, if it
+// does not exist yet.
+func getOrCreateIPAddressClaim(ctx context.Context, c client.Client, owner client.Object, key client.ObjectKey, labels map[string]string, ipamRef *infrav1alpha1.IPAMObjectReference) (*capiv1beta2.IPAddressClaim, error) {
+	ipClaim := &capiv1beta2.IPAddressClaim{}
+	if err := c.Get(ctx, key, ipClaim); err == nil {
+		return ipClaim, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	ipClaim, err := newIPAddressClaim(key, labels, ipamRef)
+	if err != nil {
+		return nil, err
+	}
+	if err := controllerutil.SetControllerReference(owner, ipClaim, c.Scheme()); err != nil {
+		return nil, fmt.Errorf("failed to set ControllerReference: %w", err)
+	}
+	if err := c.Create(ctx, ipClaim); err != nil {
+		return nil, fmt.Errorf("error creating IP: %w", err)
+	}
+	return ipClaim, nil
+}
+
+// getIPAddress returns the IPAddress allocated for the IPAddressClaim or nil if it has not been allocated yet.
+func getIPAddress(ctx context.Context, c client.Client, ipClaim *capiv1beta2.IPAddressClaim) (*capiv1beta2.IPAddress, error) {
+	if ipClaim.Status.AddressRef.Name == "" {
+		return nil, nil
+	}
+
+	ipAddr := &capiv1beta2.IPAddress{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: ipClaim.Namespace, Name: ipClaim.Status.AddressRef.Name}, ipAddr); err != nil {
+		return nil, err
+	}
+	return ipAddr, nil
+}
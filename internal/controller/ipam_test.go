@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var _ = Describe("IPAM", func() {
+	It("should suffix IPAddressClaim names with a hash", func() {
+		Expect(ipAddressClaimName("machine", "foo", 0)).To(MatchRegexp(`^machine-foo-[0-9a-f]{8}$`))
+	})
+
+	It("should not collide the names of other machines, metadata keys or addresses", func() {
+		Expect(ipAddressClaimName("a-b", "c", 0)).NotTo(Equal(ipAddressClaimName("a", "b-c", 0)))
+		Expect(ipAddressClaimName("machine", "foo", 1)).NotTo(Equal(ipAddressClaimName("machine", "foo-1", 0)))
+		Expect(ipAddressClaimName("machine", "foo", 1)).NotTo(Equal(ipAddressClaimName("machine", "foo", 2)))
+	})
+
+	It("should keep the legacy IPAddressClaim names for adoption", func() {
+		Expect(legacyIPAddressClaimName("machine", "foo", 0)).To(Equal("machine-foo"))
+		Expect(legacyIPAddressClaimName("machine", "foo", 1)).To(HavePrefix("machine-foo-"))
+	})
+
+	It("should request count addresses from each pool", func() {
+		ipv4 := &infrav1alpha1.IPAMObjectReference{Name: "ipv4"}
+		ipv6 := infrav1alpha1.IPAMObjectReference{Name: "ipv6"}
+
+		Expect(ipamConfigPools(infrav1alpha1.IPAMConfig{IPAMRef: ipv4})).To(Equal([]*infrav1alpha1.IPAMObjectReference{ipv4}))
+		Expect(ipamConfigPools(infrav1alpha1.IPAMConfig{
+			IPAMRef:  ipv4,
+			IPAMRefs: []infrav1alpha1.IPAMObjectReference{ipv6},
+			Count:    2,
+		})).To(Equal([]*infrav1alpha1.IPAMObjectReference{ipv4, ipv4, &ipv6, &ipv6}))
+	})
+
+	It("should truncate long IPAddressClaim names without collisions", func() {
+		prefix := strings.Repeat("a", validation.DNS1123SubdomainMaxLength)
+		first := ipAddressClaimName(prefix+"-1", "foo", 0)
+		second := ipAddressClaimName(prefix+"-2", "foo", 0)
+
+		Expect(first).To(HaveLen(validation.DNS1123SubdomainMaxLength))
+		Expect(second).To(HaveLen(validation.DNS1123SubdomainMaxLength))
+		Expect(first).NotTo(Equal(second))
+		Expect(validation.IsDNS1123Subdomain(first)).To(BeEmpty())
+		Expect(ipAddressClaimName(prefix+"-1", "foo", 0)).To(Equal(first))
+	})
+
+	It("should truncate the name of the control plane endpoint IPAddressClaim of a long cluster name", func() {
+		Expect(controlPlaneEndpointClaimName("cluster")).To(Equal("cluster-" + controlPlaneEndpointSuffix))
+
+		name := controlPlaneEndpointClaimName(strings.Repeat("a", validation.DNS1123SubdomainMaxLength))
+		Expect(name).To(HaveLen(validation.DNS1123SubdomainMaxLength))
+		Expect(validation.IsDNS1123Subdomain(name)).To(BeEmpty())
+	})
+
+	It("should return valid label values for long names", func() {
+		metalMachine := &infrav1alpha1.IroncoreMetalMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      strings.Repeat("a", 62) + "-machine",
+			},
+		}
+
+		for _, value := range ipAddressClaimLabels(metalMachine, "foo", 0) {
+			Expect(validation.IsValidLabelValue(value)).To(BeEmpty())
+		}
+	})
+})
@@ -12,9 +12,11 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -29,16 +31,31 @@ import (
 	infrav1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
 )
 
+const (
+	controlPlaneEndpointSuffix      = "control-plane-endpoint"
+	defaultControlPlaneEndpointPort = 6443
+
+	// defaultKeepalivedControlPlaneEndpointPort is the default port of the control plane endpoint with a Keepalived
+	// ControlPlaneLoadBalancer, haproxy listens on it next to the kube-apiserver.
+	defaultKeepalivedControlPlaneEndpointPort = 8443
+)
+
 // IroncoreMetalClusterReconciler reconciles a IroncoreMetalCluster object
 type IroncoreMetalClusterReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// MetalClusters caches the remote clusters referenced by the MetalKubeconfigSecretRef of the IroncoreMetalClusters.
+	// The remote cluster of a deleted IroncoreMetalCluster is stopped.
+	MetalClusters *MetalClusters
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalclusters/finalizers,verbs=update
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch
 
 func (r *IroncoreMetalClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -122,18 +139,52 @@ func (r *IroncoreMetalClusterReconciler) reconcileDelete(ctx context.Context, cl
 		return ctrl.Result{RequeueAfter: infrav1.DefaultReconcilerRequeue}, nil
 	}
 
+	if err := r.MetalClusters.release(ctx, r.Client, clusterScope.IroncoreMetalCluster); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	clusterScope.Info("cluster deleted successfully")
 	ctrlutil.RemoveFinalizer(clusterScope.IroncoreMetalCluster, infrav1.ClusterFinalizer)
 	return ctrl.Result{}, nil
 }
 
-//nolint:unparam
-func (r *IroncoreMetalClusterReconciler) reconcileNormal(_ context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
+func (r *IroncoreMetalClusterReconciler) reconcileNormal(ctx context.Context, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	clusterScope.Info("Reconciling IroncoreMetalCluster")
 
 	// If the IroncoreMetalCluster doesn't have our finalizer, add it.
 	ctrlutil.AddFinalizer(clusterScope.IroncoreMetalCluster, infrav1.ClusterFinalizer)
 
+	clusterScope.IroncoreMetalCluster.Status.FailureDomains = failureDomainsStatus(clusterScope.IroncoreMetalCluster)
+
+	allocated, err := r.reconcileControlPlaneEndpoint(ctx, clusterScope)
+	if err != nil {
+		clusterScope.Error(err, "failed to allocate control plane endpoint")
+		conditions.Set(clusterScope.IroncoreMetalCluster, metav1.Condition{
+			Type:    infrav1.ControlPlaneEndpointReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.ControlPlaneEndpointAllocationFailedReason,
+			Message: err.Error(),
+		})
+		return ctrl.Result{}, err
+	}
+	if !allocated {
+		clusterScope.Info("Waiting for control plane endpoint to be allocated")
+		conditions.Set(clusterScope.IroncoreMetalCluster, metav1.Condition{
+			Type:    infrav1.ControlPlaneEndpointReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.WaitingForControlPlaneEndpointReason,
+			Message: "Waiting for the IPAddressClaim of the control plane endpoint to be fulfilled",
+		})
+		conditions.Set(clusterScope.IroncoreMetalCluster, metav1.Condition{
+			Type:    infrav1.IroncoreMetalClusterReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.WaitingForControlPlaneEndpointReason,
+			Message: "Waiting for the control plane endpoint to be allocated",
+		})
+		// the owned IPAddressClaim is watched, fulfilling it triggers the next reconcile
+		return ctrl.Result{}, nil
+	}
+
 	conditions.Set(clusterScope.IroncoreMetalCluster, metav1.Condition{
 		Type:    infrav1.IroncoreMetalClusterReady,
 		Status:  metav1.ConditionTrue,
@@ -147,6 +198,57 @@ func (r *IroncoreMetalClusterReconciler) reconcileNormal(_ context.Context, clus
 	return ctrl.Result{}, nil
 }
 
+// controlPlaneEndpointClaimName returns the name of the IPAddressClaim of the control plane endpoint of an
+// IroncoreMetalCluster. It is truncated and suffixed with a hash if the name of the IroncoreMetalCluster is too long.
+func controlPlaneEndpointClaimName(metalClusterName string) string {
+	return truncateWithHash(fmt.Sprintf("%s-%s", metalClusterName, controlPlaneEndpointSuffix), validation.DNS1123SubdomainMaxLength)
+}
+
+// reconcileControlPlaneEndpoint allocates the host of the control plane endpoint from the IPAM pool referenced in
+// ControlPlaneEndpointIPAM and reports whether the control plane endpoint is available.
+func (r *IroncoreMetalClusterReconciler) reconcileControlPlaneEndpoint(ctx context.Context, clusterScope *scope.ClusterScope) (bool, error) {
+	metalCluster := clusterScope.IroncoreMetalCluster
+	if metalCluster.Spec.ControlPlaneEndpointIPAM == nil || metalCluster.Spec.ControlPlaneEndpoint.Host != "" {
+		return true, nil
+	}
+
+	ipClaimKey := client.ObjectKey{
+		Namespace: metalCluster.Namespace,
+		Name:      controlPlaneEndpointClaimName(metalCluster.Name),
+	}
+	ipClaim, err := getOrCreateIPAddressClaim(ctx, r.Client, metalCluster, ipClaimKey, map[string]string{
+		clusterv1.ClusterNameLabel: clusterScope.Name(),
+	}, metalCluster.Spec.ControlPlaneEndpointIPAM.IPAMRef)
+	if err != nil {
+		return false, err
+	}
+
+	ipAddr, err := getIPAddress(ctx, r.Client, ipClaim)
+	if err != nil || ipAddr == nil {
+		return false, err
+	}
+
+	port := metalCluster.Spec.ControlPlaneEndpointIPAM.Port
+	if port == 0 {
+		port = defaultControlPlaneEndpointPort
+		if loadBalancer := metalCluster.Spec.ControlPlaneLoadBalancer; loadBalancer != nil && loadBalancer.Type == infrav1.ControlPlaneLoadBalancerKeepalived {
+			port = defaultKeepalivedControlPlaneEndpointPort
+		}
+	}
+	metalCluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{
+		Host: ipAddr.Spec.Address,
+		Port: port,
+	}
+	clusterScope.Info("Allocated control plane endpoint", "Host", ipAddr.Spec.Address, "Port", port)
+
+	conditions.Set(metalCluster, metav1.Condition{
+		Type:   infrav1.ControlPlaneEndpointReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1.ControlPlaneEndpointAllocatedReason,
+	})
+	return true, nil
+}
+
 func (r *IroncoreMetalClusterReconciler) listIroncoreMetalMachinesForCluster(ctx context.Context, clusterScope *scope.ClusterScope) ([]infrav1.IroncoreMetalMachine, error) {
 	var machineList infrav1.IroncoreMetalMachineList
 	err := r.List(ctx, &machineList, client.InNamespace(clusterScope.Namespace()), client.MatchingLabels{
@@ -163,6 +265,7 @@ func (r *IroncoreMetalClusterReconciler) listIroncoreMetalMachinesForCluster(ctx
 func (r *IroncoreMetalClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1.IroncoreMetalCluster{}).
+		Owns(&capiv1beta2.IPAddressClaim{}).
 		WithEventFilter(predicates.ResourceNotPaused(mgr.GetScheme(), ctrl.LoggerFrom(ctx))).
 		Watches(
 			&clusterv1.Cluster{},
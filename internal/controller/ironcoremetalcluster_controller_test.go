@@ -15,7 +15,9 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -195,6 +197,107 @@ var _ = Describe("IroncoreMetalCluster Controller", func() {
 		})
 	})
 
+	Context("When failure domains are configured", func() {
+		BeforeEach(func() {
+			ironcoreCluster.Spec.FailureDomains = []infrav1.FailureDomain{{
+				Name:           "rack-1",
+				ControlPlane:   true,
+				ServerSelector: metav1.LabelSelector{MatchLabels: map[string]string{"rack": "1"}},
+			}, {
+				Name:           "rack-2",
+				ServerSelector: metav1.LabelSelector{MatchLabels: map[string]string{"rack": "2"}},
+			}}
+		})
+
+		It("Should publish the failure domains in the status", func() {
+			Expect(k8sClient.Create(ctx, ironcoreCluster)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, ironcoreCluster)).To(Succeed())
+			Expect(ironcoreCluster.Status.FailureDomains).To(Equal([]clusterv1.FailureDomain{
+				{Name: "rack-1", ControlPlane: ptr.To(true)},
+				{Name: "rack-2", ControlPlane: ptr.To(false)},
+			}))
+		})
+	})
+
+	Context("When the control plane endpoint is allocated from an IPAM pool", func() {
+		var ipAddress *capiv1beta2.IPAddress
+
+		BeforeEach(func() {
+			ironcoreCluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{}
+			ironcoreCluster.Spec.ControlPlaneEndpointIPAM = &infrav1.ControlPlaneEndpointIPAMConfig{
+				IPAMRef: &infrav1.IPAMObjectReference{
+					Name:     "vip-pool",
+					APIGroup: "ipam.cluster.x-k8s.io",
+					Kind:     "GlobalInClusterIPPool",
+				},
+			}
+
+			prefix := int32(24)
+			ipAddress = &capiv1beta2.IPAddress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterName + "-vip",
+					Namespace: namespace,
+				},
+				Spec: capiv1beta2.IPAddressSpec{
+					Address: "10.0.0.10",
+					Prefix:  &prefix,
+					ClaimRef: capiv1beta2.IPAddressClaimReference{
+						Name: clusterName + "-" + controlPlaneEndpointSuffix,
+					},
+					PoolRef: capiv1beta2.IPPoolReference{
+						Name:     "vip-pool",
+						Kind:     "GlobalInClusterIPPool",
+						APIGroup: "ipam.cluster.x-k8s.io",
+					},
+				},
+			}
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, ipAddress))).To(Succeed())
+		})
+
+		It("Should wait for the IPAddressClaim and set the control plane endpoint", func() {
+			Expect(k8sClient.Create(ctx, ironcoreCluster)).To(Succeed())
+
+			result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			By("Verifying the cluster is not provisioned yet")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, ironcoreCluster)).To(Succeed())
+			Expect(ironcoreCluster.Status.Initialization.Provisioned).To(BeNil())
+			condition := conditions.Get(ironcoreCluster, infrav1.ControlPlaneEndpointReadyCondition)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(infrav1.WaitingForControlPlaneEndpointReason))
+
+			By("Verifying the IPAddressClaim is owned by the IroncoreMetalCluster")
+			ipClaim := &capiv1beta2.IPAddressClaim{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ipAddress.Spec.ClaimRef.Name}, ipClaim)).To(Succeed())
+			Expect(ipClaim.Spec.PoolRef.Name).To(Equal("vip-pool"))
+			Expect(metav1.IsControlledBy(ipClaim, ironcoreCluster)).To(BeTrue())
+
+			By("Fulfilling the IPAddressClaim")
+			Expect(k8sClient.Create(ctx, ipAddress)).To(Succeed())
+			ipClaim.Status.AddressRef.Name = ipAddress.Name
+			Expect(k8sClient.Status().Update(ctx, ipClaim)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, ironcoreCluster)).To(Succeed())
+			Expect(ironcoreCluster.Spec.ControlPlaneEndpoint.Host).To(Equal("10.0.0.10"))
+			Expect(ironcoreCluster.Spec.ControlPlaneEndpoint.Port).To(Equal(int32(defaultControlPlaneEndpointPort)))
+			Expect(ironcoreCluster.Status.Initialization.Provisioned).To(HaveValue(BeTrue()))
+			Expect(conditions.IsTrue(ironcoreCluster, infrav1.ControlPlaneEndpointReadyCondition)).To(BeTrue())
+			Expect(conditions.IsTrue(ironcoreCluster, clusterv1.ReadyCondition)).To(BeTrue())
+		})
+	})
+
 	Context("When reconciling a delete", func() {
 		It("should NOT remove finalizer if owning CAPI Cluster is NOT deleted", func() {
 			ironcoreCluster.Finalizers = []string{infrav1.ClusterFinalizer}
@@ -5,10 +5,10 @@ package controller
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -32,18 +32,37 @@ import (
 	capiv1beta2 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // IroncoreMetalMachineReconciler reconciles a IroncoreMetalMachine object
 type IroncoreMetalMachineReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// DiskWipeImage is the image the Server is booted into to wipe its disks if the DeletionPolicy is
+	// ReleaseAfterDiskWipe.
+	DiskWipeImage string
+
+	// DiskWipeTimeout is the duration after which a disk wipe whose ServerMaintenance has not been marked as completed
+	// is given up. The ServerMaintenance is kept to quarantine the Server. It is disabled if zero.
+	DiskWipeTimeout time.Duration
+
+	// InsufficientCapacityTimeout is the duration after which an IroncoreMetalMachine whose ServerClaim can not be
+	// bound because no Server is available is marked as failed. It is disabled if zero.
+	InsufficientCapacityTimeout time.Duration
+
+	// MetalClusters caches the remote clusters referenced by the MetalKubeconfigSecretRef of the IroncoreMetalClusters.
+	// Their ServerClaims and Servers are watched.
+	MetalClusters *MetalClusters
 }
 
 const (
@@ -53,21 +72,26 @@ const (
 	fileMode                      = 0644
 	fileSystem                    = "root"
 	bootstrapDataKey              = "value"
-	metalHostnamePlaceholder      = "%24%24%7BMETAL_HOSTNAME%7D"
 	LabelKeyServerClaimName       = "metal.ironcore.dev/server-claim-name"
 	LabelKeyServerClaimNamespace  = "metal.ironcore.dev/server-claim-namespace"
+	LabelKeyIPAMMetadataKey       = "metal.ironcore.dev/ipam-metadata-key"
+	LabelKeyIPAMIndex             = "metal.ironcore.dev/ipam-index"
 )
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalmachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalmachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalmachines/finalizers,verbs=update
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines/status,verbs=patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinesets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=kubeadmcontrolplanes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=inclusterippools;globalinclusterippools,verbs=get
 // +kubebuilder:rbac:groups=metal.ironcore.dev,resources=serverclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servermaintenances,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 
@@ -137,6 +161,10 @@ func (r *IroncoreMetalMachineReconciler) Reconcile(ctx context.Context, req ctrl
 		return reconcile.Result{}, errors.Errorf("failed to create cluster scope: %+v", err)
 	}
 
+	// An unavailable metal cluster is handled once the machine scope exists, so it is surfaced on the
+	// IroncoreMetalMachine.
+	metalClient, metalNamespace, metalClusterErr := metalClusterClient(ctx, r.Client, r.MetalClusters, metalCluster)
+
 	// Create the machine scope
 	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
 		Client:               r.Client,
@@ -144,6 +172,8 @@ func (r *IroncoreMetalMachineReconciler) Reconcile(ctx context.Context, req ctrl
 		Machine:              machine,
 		IroncoreMetalCluster: metalCluster,
 		IroncoreMetalMachine: metalMachine,
+		MetalClient:          metalClient,
+		MetalNamespace:       metalNamespace,
 	})
 
 	if err != nil {
@@ -164,6 +194,10 @@ func (r *IroncoreMetalMachineReconciler) Reconcile(ctx context.Context, req ctrl
 		return reconcile.Result{}, nil
 	}
 
+	if metalClusterErr != nil {
+		return r.reconcileMetalClusterUnavailable(ctx, machineScope, metalClusterErr)
+	}
+
 	// Handle deleted machines
 	if !metalMachine.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, machineScope)
@@ -175,19 +209,226 @@ func (r *IroncoreMetalMachineReconciler) Reconcile(ctx context.Context, req ctrl
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *IroncoreMetalMachineReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	c, err := ctrl.NewControllerManagedBy(mgr).
 		For(&infrav1alpha1.IroncoreMetalMachine{}).
+		Owns(&metalv1alpha1.ServerClaim{}).
 		Watches(
 			&clusterapiv1beta2.Machine{},
 			handler.EnqueueRequestsFromMapFunc(util.MachineToInfrastructureMapFunc(infrav1alpha1.GroupVersion.WithKind("IroncoreMetalMachine"))),
 		).
-		Complete(r)
+		Watches(
+			&capiv1beta2.IPAddressClaim{},
+			handler.EnqueueRequestsFromMapFunc(r.ipAddressClaimToIroncoreMetalMachine),
+		).
+		Watches(
+			&capiv1beta2.IPAddress{},
+			handler.EnqueueRequestsFromMapFunc(r.ipAddressToIroncoreMetalMachine),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.bootstrapSecretToIroncoreMetalMachines),
+		).
+		Watches(
+			&metalv1alpha1.Server{},
+			handler.EnqueueRequestsFromMapFunc(r.serverToIroncoreMetalMachine),
+		).
+		Build(r)
+	if err != nil {
+		return err
+	}
+	if r.MetalClusters == nil {
+		return nil
+	}
+	return r.MetalClusters.addWatches(func(metalCluster cluster.Cluster) error {
+		if err := c.Watch(source.Kind(metalCluster.GetCache(), &metalv1alpha1.ServerClaim{},
+			handler.TypedEnqueueRequestsFromMapFunc(r.metalServerClaimToIroncoreMetalMachine))); err != nil {
+			return err
+		}
+		return c.Watch(source.Kind(metalCluster.GetCache(), &metalv1alpha1.Server{},
+			handler.TypedEnqueueRequestsFromMapFunc(r.metalServerToIroncoreMetalMachine(metalCluster.GetClient()))))
+	})
+}
+
+// ipAddressClaimToIroncoreMetalMachine maps an IPAddressClaim to the IroncoreMetalMachine it was created for.
+func (r *IroncoreMetalMachineReconciler) ipAddressClaimToIroncoreMetalMachine(_ context.Context, obj client.Object) []reconcile.Request {
+	for _, ownerRef := range obj.GetOwnerReferences() {
+		if ownerRef.Kind == "IroncoreMetalMachine" && ownerRef.APIVersion == infrav1alpha1.GroupVersion.String() {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: ownerRef.Name}}}
+		}
+	}
+	return nil
+}
+
+// ipAddressToIroncoreMetalMachine maps an IPAddress to the IroncoreMetalMachine of the IPAddressClaim it was
+// allocated for.
+func (r *IroncoreMetalMachineReconciler) ipAddressToIroncoreMetalMachine(ctx context.Context, obj client.Object) []reconcile.Request {
+	ipAddr, ok := obj.(*capiv1beta2.IPAddress)
+	if !ok || ipAddr.Spec.ClaimRef.Name == "" {
+		return nil
+	}
+
+	ipClaim := &capiv1beta2.IPAddressClaim{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: ipAddr.Namespace, Name: ipAddr.Spec.ClaimRef.Name}, ipClaim); err != nil {
+		return nil
+	}
+	return r.ipAddressClaimToIroncoreMetalMachine(ctx, ipClaim)
+}
+
+// bootstrapSecretToIroncoreMetalMachines maps a bootstrap data secret to the IroncoreMetalMachines of the Machines
+// referencing it.
+func (r *IroncoreMetalMachineReconciler) bootstrapSecretToIroncoreMetalMachines(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusterName, ok := obj.GetLabels()[clusterapiv1beta2.ClusterNameLabel]
+	if !ok {
+		return nil
+	}
+
+	machines := &clusterapiv1beta2.MachineList{}
+	if err := r.List(ctx, machines, client.InNamespace(obj.GetNamespace()), client.MatchingLabels{clusterapiv1beta2.ClusterNameLabel: clusterName}); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, machine := range machines.Items {
+		if ptr.Deref(machine.Spec.Bootstrap.DataSecretName, "") != obj.GetName() {
+			continue
+		}
+		if machine.Spec.InfrastructureRef.GroupKind() != infrav1alpha1.GroupVersion.WithKind("IroncoreMetalMachine").GroupKind() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: machine.Namespace, Name: machine.Spec.InfrastructureRef.Name},
+		})
+	}
+	return requests
+}
+
+// serverToIroncoreMetalMachine maps a Server to the IroncoreMetalMachine controlling the ServerClaim it is bound to.
+func (r *IroncoreMetalMachineReconciler) serverToIroncoreMetalMachine(ctx context.Context, obj client.Object) []reconcile.Request {
+	server, ok := obj.(*metalv1alpha1.Server)
+	if !ok || server.Spec.ServerClaimRef == nil {
+		return nil
+	}
+
+	serverClaim := &metalv1alpha1.ServerClaim{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: server.Spec.ServerClaimRef.Namespace, Name: server.Spec.ServerClaimRef.Name}, serverClaim); err != nil {
+		return nil
+	}
+	ownerRef := metav1.GetControllerOf(serverClaim)
+	if ownerRef == nil || ownerRef.Kind != "IroncoreMetalMachine" || ownerRef.APIVersion != infrav1alpha1.GroupVersion.String() {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: serverClaim.Namespace, Name: ownerRef.Name}}}
 }
 
+// metalServerClaimToIroncoreMetalMachine maps a ServerClaim in a remote cluster to the IroncoreMetalMachine of its
+// metalObjectLabels, as it can not be owned by the IroncoreMetalMachine.
+func (r *IroncoreMetalMachineReconciler) metalServerClaimToIroncoreMetalMachine(ctx context.Context, serverClaim *metalv1alpha1.ServerClaim) []reconcile.Request {
+	namespace, name := serverClaim.Labels[LabelKeyServerClaimNamespace], serverClaim.Labels[LabelKeyServerClaimName]
+	if namespace == "" || name == "" {
+		return nil
+	}
+
+	metalMachines := &infrav1alpha1.IroncoreMetalMachineList{}
+	if err := r.List(ctx, metalMachines, client.InNamespace(namespace)); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, metalMachine := range metalMachines.Items {
+		if truncateWithHash(metalMachine.Name, validation.LabelValueMaxLength) == name {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&metalMachine)})
+		}
+	}
+	return requests
+}
+
+// metalServerToIroncoreMetalMachine maps a Server in a remote cluster to the IroncoreMetalMachine of the ServerClaim it
+// is bound to, which is read with the client of the remote cluster.
+func (r *IroncoreMetalMachineReconciler) metalServerToIroncoreMetalMachine(metalClient client.Client) handler.TypedMapFunc[*metalv1alpha1.Server, reconcile.Request] {
+	return func(ctx context.Context, server *metalv1alpha1.Server) []reconcile.Request {
+		if server.Spec.ServerClaimRef == nil {
+			return nil
+		}
+		serverClaim := &metalv1alpha1.ServerClaim{}
+		if err := metalClient.Get(ctx, client.ObjectKey{Namespace: server.Spec.ServerClaimRef.Namespace, Name: server.Spec.ServerClaimRef.Name}, serverClaim); err != nil {
+			return nil
+		}
+		return r.metalServerClaimToIroncoreMetalMachine(ctx, serverClaim)
+	}
+}
+
+// reconcileDelete tears down the resources of an IroncoreMetalMachine in order: the ServerClaim is deleted first
+// and the Server has to be released and powered off before the IPAddressClaims and the ignition secret are removed.
+// The finalizer is only removed once all of these resources are gone.
 func (r *IroncoreMetalMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope) (ctrl.Result, error) {
 	machineScope.Info("Deleting IroncoreMetalMachine")
 
-	// insert ServerClaim deletion logic here
+	serverMaintained, err := r.ensureServerMaintenance(ctx, machineScope)
+	if err != nil {
+		machineScope.Error(err, "failed to ensure ServerMaintenance")
+		return ctrl.Result{}, err
+	}
+	if !serverMaintained {
+		reason, message := infrav1alpha1.WaitingForServerMaintenanceReason, "Waiting for the Server to be in maintenance"
+		if machineScope.IroncoreMetalMachine.Spec.DeletionPolicy == infrav1alpha1.DeletionPolicyReleaseAfterDiskWipe {
+			reason, message = infrav1alpha1.WaitingForDiskWipeReason, "Waiting for the disks of the Server to be wiped"
+		}
+		machineScope.Info(message)
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		})
+		return ctrl.Result{RequeueAfter: infrav1alpha1.DefaultReconcilerRequeue}, nil
+	}
+
+	serverClaimDeleted, err := r.ensureServerClaimDeleted(ctx, machineScope)
+	if err != nil {
+		machineScope.Error(err, "failed to delete ServerClaim")
+		return ctrl.Result{}, err
+	}
+	if !serverClaimDeleted {
+		machineScope.Info("Waiting for ServerClaim to be deleted")
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerClaimBoundCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.ServerClaimDeletingReason,
+			Message: "Waiting for the ServerClaim to be deleted",
+		})
+		return ctrl.Result{RequeueAfter: infrav1alpha1.DefaultReconcilerRequeue}, nil
+	}
+
+	serverReleased, err := r.ensureServerReleased(ctx, machineScope)
+	if err != nil {
+		machineScope.Error(err, "failed to check Server release")
+		return ctrl.Result{}, err
+	}
+	if !serverReleased {
+		machineScope.Info("Waiting for Server to be released and powered off", "Server", machineScope.IroncoreMetalMachine.Status.ServerRef.Name)
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.WaitingForServerReleaseReason,
+			Message: fmt.Sprintf("Waiting for Server %s to be released and powered off", machineScope.IroncoreMetalMachine.Status.ServerRef.Name),
+		})
+		return ctrl.Result{RequeueAfter: infrav1alpha1.DefaultReconcilerRequeue}, nil
+	}
+	machineScope.IroncoreMetalMachine.Status.ServerRef = nil
+
+	ipAddressClaimsDeleted, err := r.ensureIPAddressClaimsDeleted(ctx, machineScope)
+	if err != nil {
+		machineScope.Error(err, "failed to delete IPAddressClaims")
+		return ctrl.Result{}, err
+	}
+	if !ipAddressClaimsDeleted {
+		machineScope.Info("Waiting for IPAddressClaims to be deleted")
+		return ctrl.Result{RequeueAfter: infrav1alpha1.DefaultReconcilerRequeue}, nil
+	}
+
+	if err := r.ensureIgnitionSecretDeleted(ctx, machineScope); err != nil {
+		machineScope.Error(err, "failed to delete ignition secret")
+		return ctrl.Result{}, err
+	}
 
 	if modified, err := clientutils.PatchEnsureNoFinalizer(ctx, r.Client, machineScope.IroncoreMetalMachine, IroncoreMetalMachineFinalizer); !apierrors.IsNotFound(err) || modified {
 		return ctrl.Result{}, err
@@ -197,19 +438,113 @@ func (r *IroncoreMetalMachineReconciler) reconcileDelete(ctx context.Context, ma
 	return reconcile.Result{RequeueAfter: infrav1alpha1.DefaultReconcilerRequeue}, nil
 }
 
+// ensureServerClaimDeleted issues the deletion of the ServerClaim and reports whether it is gone.
+func (r *IroncoreMetalMachineReconciler) ensureServerClaimDeleted(ctx context.Context, machineScope *scope.MachineScope) (bool, error) {
+	serverClaim := &metalv1alpha1.ServerClaim{}
+	if err := getMetalObject(ctx, machineScope, serverClaimKey(machineScope), serverClaim); err != nil {
+		return apierrors.IsNotFound(err), client.IgnoreNotFound(err)
+	}
+
+	// remember the bound Server, the reference is gone together with the ServerClaim
+	if serverClaim.Spec.ServerRef != nil && machineScope.IroncoreMetalMachine.Status.ServerRef == nil {
+		machineScope.IroncoreMetalMachine.Status.ServerRef = &corev1.LocalObjectReference{Name: serverClaim.Spec.ServerRef.Name}
+	}
+
+	if serverClaim.DeletionTimestamp.IsZero() {
+		machineScope.Info("Deleting ServerClaim", "ServerClaim", serverClaim.Name)
+		if err := machineScope.MetalClient.Delete(ctx, serverClaim); client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("failed to delete ServerClaim: %w", err)
+		}
+	}
+	return false, nil
+}
+
+// ensureServerReleased reports whether the Server formerly bound to the ServerClaim is neither reserved nor powered on anymore.
+// A Server kept for inspection or quarantined after a timed out disk wipe is released once it is in maintenance.
+func (r *IroncoreMetalMachineReconciler) ensureServerReleased(ctx context.Context, machineScope *scope.MachineScope) (bool, error) {
+	serverRef := machineScope.IroncoreMetalMachine.Status.ServerRef
+	if serverRef == nil {
+		return true, nil
+	}
+
+	server := &metalv1alpha1.Server{}
+	if err := machineScope.MetalClient.Get(ctx, client.ObjectKey{Name: serverRef.Name}, server); err != nil {
+		return apierrors.IsNotFound(err), client.IgnoreNotFound(err)
+	}
+
+	if claimRef := server.Spec.ServerClaimRef; claimRef != nil &&
+		(client.ObjectKey{Namespace: claimRef.Namespace, Name: claimRef.Name}) != serverClaimKey(machineScope) {
+		// the Server has already been released and claimed by someone else
+		return true, nil
+	}
+
+	if machineScope.IroncoreMetalMachine.Spec.DeletionPolicy != infrav1alpha1.DeletionPolicyRelease &&
+		server.Status.State == metalv1alpha1.ServerStateMaintenance {
+		return true, nil
+	}
+
+	return server.Status.State != metalv1alpha1.ServerStateReserved &&
+		server.Status.PowerState == metalv1alpha1.ServerOffPowerState, nil
+}
+
+// ensureIPAddressClaimsDeleted deletes the IPAddressClaims of the IroncoreMetalMachine and reports whether they are gone.
+func (r *IroncoreMetalMachineReconciler) ensureIPAddressClaimsDeleted(ctx context.Context, machineScope *scope.MachineScope) (bool, error) {
+	ipAddressClaims := &capiv1beta2.IPAddressClaimList{}
+	if err := r.List(ctx, ipAddressClaims, client.InNamespace(machineScope.IroncoreMetalMachine.Namespace), client.MatchingLabels{
+		LabelKeyServerClaimName:      truncateWithHash(machineScope.IroncoreMetalMachine.Name, validation.LabelValueMaxLength),
+		LabelKeyServerClaimNamespace: machineScope.IroncoreMetalMachine.Namespace,
+	}); err != nil {
+		return false, fmt.Errorf("failed to list IPAddressClaims: %w", err)
+	}
+
+	for _, ipAddressClaim := range ipAddressClaims.Items {
+		if !ipAddressClaim.DeletionTimestamp.IsZero() {
+			continue
+		}
+		machineScope.Info("Deleting IPAddressClaim", "IPAddressClaim", ipAddressClaim.Name)
+		if err := r.Delete(ctx, &ipAddressClaim); client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("failed to delete IPAddressClaim %s: %w", ipAddressClaim.Name, err)
+		}
+	}
+	return len(ipAddressClaims.Items) == 0, nil
+}
+
+// ensureIgnitionSecretDeleted deletes the ignition secret rendered for the IroncoreMetalMachine.
+func (r *IroncoreMetalMachineReconciler) ensureIgnitionSecretDeleted(ctx context.Context, machineScope *scope.MachineScope) error {
+	ignitionSecret := &corev1.Secret{}
+	ignitionSecretKey := client.ObjectKey{Namespace: machineScope.MetalNamespace, Name: ignitionSecretName(machineScope)}
+	if err := getMetalObject(ctx, machineScope, ignitionSecretKey, ignitionSecret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if err := machineScope.MetalClient.Delete(ctx, ignitionSecret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete ignition secret: %w", err)
+	}
+	return nil
+}
+
 func (r *IroncoreMetalMachineReconciler) reconcileNormal(ctx context.Context, machineScope *scope.MachineScope, clusterScope *scope.ClusterScope) (reconcile.Result, error) {
 	clusterScope.Logger.V(4).Info("Reconciling IroncoreMetalMachine")
 
 	if !ptr.Deref(machineScope.Cluster.Status.Initialization.InfrastructureProvisioned, false) {
 		machineScope.Info("Cluster infrastructure is not ready yet")
-		// TBD: update conditions
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerClaimBoundCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.WaitingForClusterInfrastructureReason,
+			Message: "Waiting for the Cluster infrastructure to be provisioned",
+		})
 		return ctrl.Result{}, nil
 	}
 
 	// Make sure bootstrap data is available and populated.
 	if machineScope.Machine.Spec.Bootstrap.DataSecretName == nil {
 		machineScope.Info("Bootstrap data secret reference is not yet available")
-		// TBD: update conditions
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.BootstrapDataReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.WaitingForBootstrapDataReason,
+			Message: "Waiting for the bootstrap provider to set the bootstrap data secret reference on the Machine",
+		})
 		return ctrl.Result{}, nil
 	}
 
@@ -226,52 +561,279 @@ func (r *IroncoreMetalMachineReconciler) reconcileNormal(ctx context.Context, ma
 	}
 	if err := r.Get(ctx, secretName, bootstrapSecret); err != nil {
 		machineScope.Error(err, "failed to get bootstrap data secret")
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.BootstrapDataReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.BootstrapDataSecretUnavailableReason,
+			Message: fmt.Sprintf("Failed to get bootstrap data secret %s: %v", secretName.Name, err),
+		})
 		return ctrl.Result{}, err
 	}
+	conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+		Type:   infrav1alpha1.BootstrapDataReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1alpha1.BootstrapDataAvailableReason,
+	})
 
-	ipAddressClaims, IPAddressesMetadata, err := r.getOrCreateIPAddressClaims(ctx, machineScope.Logger, machineScope.IroncoreMetalMachine)
+	ipAddressClaims, IPAddressesMetadata, pendingIPAddressClaims, err := r.getOrCreateIPAddressClaims(ctx, machineScope.Logger, machineScope.IroncoreMetalMachine)
 	if err != nil {
 		machineScope.Error(err, "failed to get or create IPAddressClaims")
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.IPAddressesAllocatedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.IPAddressClaimFailedReason,
+			Message: err.Error(),
+		})
+		return ctrl.Result{}, err
+	}
+	if len(pendingIPAddressClaims) > 0 {
+		// the IPAddressClaims and IPAddresses are watched, the allocation triggers the next reconcile
+		machineScope.Info("Waiting for IPAddressClaims to be fulfilled", "IPAddressClaims", pendingIPAddressClaims)
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.IPAddressesAllocatedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.WaitingForIPAMReason,
+			Message: fmt.Sprintf("Waiting for IPAddressClaims %s to be fulfilled", strings.Join(pendingIPAddressClaims, ", ")),
+		})
+		return ctrl.Result{}, nil
+	}
+	conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+		Type:   infrav1alpha1.IPAddressesAllocatedCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1alpha1.IPAddressesAllocatedReason,
+	})
+
+	server, err := r.getBoundServer(ctx, machineScope)
+	if err != nil {
+		machineScope.Error(err, "failed to get the Server bound to the ServerClaim")
 		return ctrl.Result{}, err
 	}
 
-	machineScope.Info("Creating an ignition", "Machine", machineScope.IroncoreMetalMachine.Name)
-	ignition, err := r.createIgnition(machineScope.IroncoreMetalMachine, bootstrapSecret.Data[bootstrapDataKey], IPAddressesMetadata)
+	variables, err := bootstrapDataVariables(machineScope, IPAddressesMetadata, server)
 	if err != nil {
+		machineScope.Error(err, "failed to collect the bootstrap data variables")
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.IgnitionReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.IgnitionCreationFailedReason,
+			Message: err.Error(),
+		})
+		return ctrl.Result{}, err
+	}
+
+	ignitionSecretName := ignitionSecretName(machineScope)
+	serverPower := requestedServerPower(machineScope.IroncoreMetalMachine)
+	waitingForServerVariables := false
+
+	var ignition []byte
+	driftedIgnition := false
+	retired := ignitionSecretRetired(machineScope)
+	if !retired {
+		machineScope.Info("Creating an ignition", "Machine", machineScope.IroncoreMetalMachine.Name)
+		ignition, err = r.createIgnition(machineScope, bootstrapSecret, IPAddressesMetadata, variables)
+	}
+	var unresolvedErr *unresolvedVariablesError
+	switch {
+	case retired:
+		reason, err := r.retireIgnitionSecret(ctx, machineScope)
+		if err != nil {
+			machineScope.Error(err, "failed to apply the IgnitionSecretPolicy")
+			return ctrl.Result{}, err
+		}
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:   infrav1alpha1.IgnitionReadyCondition,
+			Status: metav1.ConditionTrue,
+			Reason: reason,
+		})
+	case errors.As(err, &unresolvedErr) && server == nil && unresolvedErr.onlyServerVariables():
+		// the Server variables can only be resolved once the ServerClaim is bound, keep the Server powered off until
+		// the ignition is complete
+		machineScope.Info("Waiting for the Server to resolve the ignition variables", "Variables", unresolvedErr.names)
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.IgnitionReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.WaitingForServerReason,
+			Message: fmt.Sprintf("Waiting for the ServerClaim to be bound to resolve %s", strings.Join(unresolvedErr.names, ", ")),
+		})
+		serverPower = metalv1alpha1.PowerOff
+		waitingForServerVariables = true
+	case errors.As(err, &unresolvedErr):
+		machineScope.Info("Bootstrap data contains unresolved variables", "Variables", unresolvedErr.names)
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.IgnitionReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.UnresolvedVariablesReason,
+			Message: unresolvedErr.Error(),
+		})
+		return ctrl.Result{}, nil
+	case err != nil:
 		machineScope.Error(err, "failed to create an ignition")
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.IgnitionReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.IgnitionCreationFailedReason,
+			Message: err.Error(),
+		})
 		return ctrl.Result{}, err
+	case ignitionDrifted(machineScope.IroncoreMetalMachine, ignition):
+		// the Server has been provisioned with the recorded ignition, it is not changed in place
+		machineScope.Info("Ignition has changed after the machine was provisioned, keeping the IgnitionSecret")
+		driftedIgnition = true
+	default:
+		machineScope.Info("Creating IgnitionSecret", "Secret", machineScope.IroncoreMetalMachine.Name)
+		if _, err := r.applyIgnitionSecret(ctx, machineScope, bootstrapSecret, ignition); err != nil {
+			machineScope.Error(err, "failed to create or patch ignition secret")
+			conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+				Type:    infrav1alpha1.IgnitionReadyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1alpha1.IgnitionCreationFailedReason,
+				Message: err.Error(),
+			})
+			return ctrl.Result{}, err
+		}
+		machineScope.IroncoreMetalMachine.Status.IgnitionHash = ignitionHash(ignition)
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:   infrav1alpha1.IgnitionReadyCondition,
+			Status: metav1.ConditionTrue,
+			Reason: infrav1alpha1.IgnitionCreatedReason,
+		})
+	}
+
+	serverSelector, err := serverSelectorForMachine(machineScope)
+	if err != nil {
+		machineScope.Error(err, "failed to determine the ServerSelector")
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerClaimBoundCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.ServerClaimFailedReason,
+			Message: err.Error(),
+		})
+		return ctrl.Result{}, err
+	}
+
+	if machineScope.IroncoreMetalMachine.Status.FailureReason != nil {
+		recovered, err := r.reconcileProvisioningFailure(ctx, machineScope, serverSelector)
+		if err != nil {
+			machineScope.Error(err, "failed to reconcile the failure of the machine")
+			return ctrl.Result{}, err
+		}
+		if !recovered {
+			machineScope.Info("Machine has failed, waiting for a Server to become available or the machine to be remediated")
+			return ctrl.Result{RequeueAfter: provisioningFailureRequeue}, nil
+		}
 	}
 
-	machineScope.Info("Creating IgnitionSecret", "Secret", machineScope.IroncoreMetalMachine.Name)
-	ignitionSecret, err := r.applyIgnitionSecret(ctx, machineScope.Logger, bootstrapSecret, ignition)
+	serverRef, err := r.serverAffinityRef(ctx, machineScope, serverSelector)
 	if err != nil {
-		machineScope.Error(err, "failed to create or patch ignition secret")
+		machineScope.Error(err, "failed to determine the Server of the replaced machine")
 		return ctrl.Result{}, err
 	}
 
 	machineScope.Info("Creating ServerClaim", "ServerClaim", machineScope.IroncoreMetalMachine.Name)
-	serverClaim, err := r.applyServerClaim(ctx, machineScope.Logger, machineScope.IroncoreMetalMachine, ignitionSecret)
+	serverClaim, err := r.applyServerClaim(ctx, machineScope, serverRef, serverSelector, ignitionSecretName, serverPower)
 	if err != nil {
 		machineScope.Error(err, "failed to create or patch ServerClaim")
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerClaimBoundCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.ServerClaimFailedReason,
+			Message: err.Error(),
+		})
 		return ctrl.Result{}, err
 	}
 
-	err = r.setServerClaimOwnership(ctx, serverClaim, ipAddressClaims)
-	if err != nil {
-		machineScope.Error(err, "failed to set ServerClaim ownership")
+	// owner references can not cross clusters, the IPAddressClaims stay owned by the IroncoreMetalMachine only
+	if !machineScope.RemoteMetalCluster() {
+		if err := r.setServerClaimOwnership(ctx, serverClaim, ipAddressClaims); err != nil {
+			machineScope.Error(err, "failed to set ServerClaim ownership")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := reconcileDrift(machineScope, driftedIgnition, serverSelector); err != nil {
+		machineScope.Error(err, "failed to check the machine for a drift")
 		return ctrl.Result{}, err
 	}
 
-	bound, _ := r.ensureServerClaimBound(ctx, serverClaim)
+	bound, _ := r.ensureServerClaimBound(ctx, machineScope, serverClaim)
 	if !bound {
+		insufficient, err := r.insufficientCapacity(ctx, machineScope, serverClaim)
+		if err != nil {
+			machineScope.Error(err, "failed to check the available Servers")
+			return ctrl.Result{}, err
+		}
+		if insufficient {
+			return r.reportInsufficientCapacity(ctx, machineScope, serverClaim)
+		}
 		machineScope.Info("Waiting for ServerClaim to be Bound")
-		return ctrl.Result{
-			RequeueAfter: infrav1alpha1.DefaultReconcilerRequeue,
-		}, nil
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerClaimBoundCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.WaitingForServerClaimBindingReason,
+			Message: fmt.Sprintf("Waiting for ServerClaim %s to be bound to a Server", serverClaim.Name),
+		})
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.WaitingForServerReason,
+			Message: "Waiting for the ServerClaim to be bound",
+		})
+		// the owned ServerClaim is watched, binding it triggers the next reconcile
+		return ctrl.Result{}, nil
+	}
+	conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+		Type:   infrav1alpha1.ServerClaimBoundCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1alpha1.ServerClaimBoundReason,
+	})
+	if serverClaim.Spec.ServerRef != nil {
+		machineScope.IroncoreMetalMachine.Status.ServerRef = &corev1.LocalObjectReference{Name: serverClaim.Spec.ServerRef.Name}
+	}
+
+	if server == nil {
+		if server, err = r.getBoundServer(ctx, machineScope); err != nil {
+			machineScope.Error(err, "failed to get the Server bound to the ServerClaim")
+			return ctrl.Result{}, err
+		}
+	}
+	machineScope.IroncoreMetalMachine.Status.Addresses = machineAddresses(machineScope.IroncoreMetalMachine.Name, IPAddressesMetadata, server)
+	if server != nil {
+		machineScope.IroncoreMetalMachine.Status.PowerState = server.Status.PowerState
+	}
+	if err := r.ensureServerAffinityLabel(ctx, machineScope, server); err != nil {
+		machineScope.Error(err, "failed to label the Server for the replacement of the machine")
+		return ctrl.Result{}, err
+	}
+
+	if waitingForServerVariables {
+		machineScope.Info("Requeueing to resolve the ignition variables of the bound Server")
+		return ctrl.Result{RequeueAfter: infrav1alpha1.DefaultReconcilerRequeue}, nil
+	}
+
+	if serverPower == metalv1alpha1.PowerOff {
+		// the Server is watched, powering it on again triggers the next reconcile
+		machineScope.Info("Server is powered off as requested")
+		conditions.Set(machineScope.IroncoreMetalMachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.ServerPoweredOffReason,
+			Message: fmt.Sprintf("Server is powered off as requested by the %s annotation", infrav1alpha1.PowerAnnotation),
+		})
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.reconcileServerOperation(ctx, machineScope, server); err != nil {
+		machineScope.Error(err, "failed to pass the requested operation to the Server")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileServerReadyCondition(ctx, machineScope, serverClaim); err != nil {
+		machineScope.Error(err, "failed to reconcile the ServerReady condition")
+		return ctrl.Result{}, err
 	}
 
 	machineScope.Info("Patching ProviderID in IroncoreMetalMachine")
-	if err := r.patchIroncoreMetalMachineProviderID(ctx, machineScope.Logger, machineScope.IroncoreMetalMachine, serverClaim); err != nil {
+	if err := r.patchIroncoreMetalMachineProviderID(ctx, machineScope); err != nil {
 		machineScope.Error(err, "failed to patch the IroncoreMetalMachine with providerid")
 		return ctrl.Result{}, err
 	}
@@ -283,14 +845,55 @@ func (r *IroncoreMetalMachineReconciler) reconcileNormal(ctx context.Context, ma
 	return reconcile.Result{}, nil
 }
 
-func (r *IroncoreMetalMachineReconciler) createIgnition(ironcoremetalmachine *infrav1alpha1.IroncoreMetalMachine, ignition []byte, IPAddressesMetadata map[string]any) ([]byte, error) {
-	ignition = findAndReplaceIgnition(ironcoremetalmachine, ignition)
+// reconcileServerReadyCondition reflects the power state of the Server bound to the ServerClaim in the
+// ServerReady condition of the IroncoreMetalMachine.
+func (r *IroncoreMetalMachineReconciler) reconcileServerReadyCondition(ctx context.Context, machineScope *scope.MachineScope, serverClaim *metalv1alpha1.ServerClaim) error {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+	if serverClaim.Spec.ServerRef == nil {
+		conditions.Set(ironcoremetalmachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.WaitingForServerReason,
+			Message: fmt.Sprintf("ServerClaim %s does not reference a Server yet", serverClaim.Name),
+		})
+		return nil
+	}
 
-	ignitionMap := make(map[string]any)
-	if err := json.Unmarshal(ignition, &ignitionMap); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal secret data: %w", err)
+	server := &metalv1alpha1.Server{}
+	if err := machineScope.MetalClient.Get(ctx, client.ObjectKey{Name: serverClaim.Spec.ServerRef.Name}, server); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		conditions.Set(ironcoremetalmachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.WaitingForServerReason,
+			Message: fmt.Sprintf("Server %s not found", serverClaim.Spec.ServerRef.Name),
+		})
+		return nil
 	}
 
+	if server.Status.PowerState != metalv1alpha1.ServerOnPowerState {
+		conditions.Set(ironcoremetalmachine, metav1.Condition{
+			Type:    infrav1alpha1.ServerReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1alpha1.WaitingForServerPowerOnReason,
+			Message: fmt.Sprintf("Server %s is in power state %q", server.Name, server.Status.PowerState),
+		})
+		return nil
+	}
+
+	conditions.Set(ironcoremetalmachine, metav1.Condition{
+		Type:   infrav1alpha1.ServerReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1alpha1.ServerPoweredOnReason,
+	})
+	return nil
+}
+
+func (r *IroncoreMetalMachineReconciler) createIgnition(machineScope *scope.MachineScope, bootstrapSecret *corev1.Secret, IPAddressesMetadata map[string]any, variables map[string]string) ([]byte, error) {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+
 	metaDataMap := make(map[string]any)
 	if ironcoremetalmachine.Spec.Metadata != nil {
 		if err := json.Unmarshal(ironcoremetalmachine.Spec.Metadata.Raw, &metaDataMap); err != nil {
@@ -298,199 +901,326 @@ func (r *IroncoreMetalMachineReconciler) createIgnition(ironcoremetalmachine *in
 		}
 	}
 	maps.Copy(metaDataMap, IPAddressesMetadata)
+	services := addNetworkServicesMetadata(metaDataMap, IPAddressesMetadata, &machineScope.IroncoreMetalCluster.Spec)
 	metaData, err := json.Marshal(metaDataMap)
 	if err != nil {
 		return nil, fmt.Errorf("failed to apply IPAddresses: %w", err)
 	}
 
+	var files []staticFile
 	if len(metaData) > 2 { // check if metaData is not an empty json object e.g. {}
-		metaDataConf := map[string]any{
+		files = append(files, staticFile{Path: metaDataFile, Mode: fileMode, Data: metaData})
+	}
+
+	networkFiles, err := networkConfigFiles(ironcoremetalmachine.Spec.NetworkConfig, IPAddressesMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render network config: %w", err)
+	}
+	files = append(files, networkFiles...)
+
+	servicesFiles, err := networkServicesFiles(&machineScope.IroncoreMetalCluster.Spec, services)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render DNS and NTP config: %w", err)
+	}
+	files = append(files, servicesFiles...)
+
+	if machineScope.IsControlPlane() {
+		loadBalancerFiles, err := controlPlaneLoadBalancerFiles(machineScope.IroncoreMetalCluster, isKubeadmInit(bootstrapSecret.Data[bootstrapDataKey]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to render control plane load balancer: %w", err)
+		}
+		files = append(files, loadBalancerFiles...)
+	}
+
+	if string(bootstrapSecret.Data[bootstrapFormatKey]) == cloudConfigFormat {
+		cloudConfig, err := renderBootstrapData(bootstrapSecret.Data[bootstrapDataKey], variables, noEscape)
+		if err != nil {
+			return nil, err
+		}
+		return createCloudConfig(ironcoremetalmachine.Name, cloudConfig, files)
+	}
+
+	ignition, err := renderBootstrapData(bootstrapSecret.Data[bootstrapDataKey], variables, escapeJSONString)
+	if err != nil {
+		return nil, err
+	}
+
+	ignitionMap := make(map[string]any)
+	if err := json.Unmarshal(ignition, &ignitionMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secret data: %w", err)
+	}
+
+	if version := ironcoremetalmachine.Spec.IgnitionVersion; version != "" && !isIgnitionV3(ignitionVersion(ignitionMap)) {
+		if err := translateIgnitionV2ToV3(ignitionMap, version); err != nil {
+			return nil, fmt.Errorf("failed to translate ignition to version %s: %w", version, err)
+		}
+	}
+
+	if len(files) > 0 {
+		version := ignitionVersion(ignitionMap)
+		ignitionFiles := make([]any, 0, len(files))
+		for _, file := range files {
+			ignitionFiles = append(ignitionFiles, ignitionFile(file, version))
+		}
+		filesConf := map[string]any{
 			"storage": map[string]any{
-				"files": []any{map[string]any{
-					"path": metaDataFile,
-					"mode": fileMode,
-					// TODO: remove fileSystem after Ignition 3.x is supported
-					"filesystem": fileSystem,
-					"contents": map[string]any{
-						"compression": "",
-						"source":      "data:;base64," + base64.StdEncoding.EncodeToString(metaData),
-					},
-				}},
+				"files": ignitionFiles,
 			},
 		}
-		// merge metaData configuration with ignition content
-		if err := mergo.Merge(&ignitionMap, metaDataConf, mergo.WithAppendSlice); err != nil {
-			return nil, fmt.Errorf("failed to merge metaData configuration with ignition content: %w", err)
+		// merge the files with ignition content
+		if err := mergo.Merge(&ignitionMap, filesConf, mergo.WithAppendSlice); err != nil {
+			return nil, fmt.Errorf("failed to merge files with ignition content: %w", err)
 		}
 	}
 
 	return json.Marshal(ignitionMap)
 }
 
-func (r *IroncoreMetalMachineReconciler) getOrCreateIPAddressClaims(ctx context.Context, log *logr.Logger, ironcoremetalmachine *infrav1alpha1.IroncoreMetalMachine) ([]*capiv1beta2.IPAddressClaim, map[string]any, error) {
+// getOrCreateIPAddressClaims creates the IPAddressClaims of the IPAMConfig without waiting for them to be fulfilled.
+// It returns the fulfilled IPAddressClaims with the metadata of their IPAddresses and the names of the IPAddressClaims
+// which have no IPAddress assigned yet. The metadata of a MetadataKey contains the first address, the list of all
+// addresses allocated for it and the DNS and NTP servers the IPAddresses or their pools are annotated with.
+func (r *IroncoreMetalMachineReconciler) getOrCreateIPAddressClaims(ctx context.Context, log *logr.Logger, ironcoremetalmachine *infrav1alpha1.IroncoreMetalMachine) ([]*capiv1beta2.IPAddressClaim, map[string]any, []string, error) {
 	IPAddressClaims := []*capiv1beta2.IPAddressClaim{}
 	IPAddressesMetadata := make(map[string]any)
+	var pendingIPAddressClaims []string
 
 	for _, networkRef := range ironcoremetalmachine.Spec.IPAMConfig {
-		ipAddrClaimName := fmt.Sprintf("%s-%s", ironcoremetalmachine.Name, networkRef.MetadataKey)
-		if len(ipAddrClaimName) > validation.DNS1123SubdomainMaxLength {
-			log.Info("IP address claim name is too long, it will be shortened which can cause name collisions", "name", ipAddrClaimName)
-			ipAddrClaimName = ipAddrClaimName[:validation.DNS1123SubdomainMaxLength]
-		}
-
-		ipAddrClaimKey := client.ObjectKey{Namespace: ironcoremetalmachine.Namespace, Name: ipAddrClaimName}
-		ipClaim := &capiv1beta2.IPAddressClaim{}
-		if err := r.Get(ctx, ipAddrClaimKey, ipClaim); err != nil && !apierrors.IsNotFound(err) {
-			return nil, nil, err
-
-		} else if err == nil {
-			log.V(3).Info("IP address claim found", "IP", ipAddrClaimKey.String())
-			if ipClaim.Status.AddressRef.Name == "" {
-				return nil, nil, fmt.Errorf("IP address claim %q has no IP address reference", ipAddrClaimKey.String())
-			}
-
-			if ipClaim.Labels == nil {
-				return nil, nil, fmt.Errorf("IP address claim %q has no server claim labels", ipAddrClaimKey.String())
+		var addresses []any
+		var services networkServices
+		for index, ipamRef := range ipamConfigPools(networkRef) {
+			ipClaim, err := r.findIPAddressClaim(ctx, ironcoremetalmachine, networkRef.MetadataKey, index)
+			if err != nil {
+				return nil, nil, nil, err
 			}
-			name, nameExists := ipClaim.Labels[LabelKeyServerClaimName]
-			namespace, namespaceExists := ipClaim.Labels[LabelKeyServerClaimNamespace]
-			if !nameExists || !namespaceExists {
-				return nil, nil, fmt.Errorf("IP address claim %q has no server claim labels", ipAddrClaimKey.String())
+			if ipClaim == nil {
+				ipAddrClaimKey := client.ObjectKey{
+					Namespace: ironcoremetalmachine.Namespace,
+					Name:      ipAddressClaimName(ironcoremetalmachine.Name, networkRef.MetadataKey, index),
+				}
+				log.V(3).Info("creating IP address claim", "name", ipAddrClaimKey.String())
+				ipClaim, err = newIPAddressClaim(ipAddrClaimKey, ipAddressClaimLabels(ironcoremetalmachine, networkRef.MetadataKey, index), ipamRef)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				if err := controllerutil.SetOwnerReference(ironcoremetalmachine, ipClaim, r.Client.Scheme()); err != nil {
+					return nil, nil, nil, fmt.Errorf("failed to set OwnerReference: %w", err)
+				}
+				if err = r.Create(ctx, ipClaim); err != nil {
+					return nil, nil, nil, fmt.Errorf("error creating IP: %w", err)
+				}
+			} else {
+				log.V(3).Info("IP address claim found", "IP", client.ObjectKeyFromObject(ipClaim).String())
 			}
-			if name != ironcoremetalmachine.Name || namespace != ironcoremetalmachine.Namespace {
-				return nil, nil, fmt.Errorf("IP address claim %q's server claim labels don't match. Expected: name: %q, namespace: %q. Actual: name: %q, namespace: %q", ipAddrClaimKey.String(), ironcoremetalmachine.Name, ironcoremetalmachine.Namespace, name, namespace)
+
+			ipAddr, err := getIPAddress(ctx, r.Client, ipClaim)
+			if apierrors.IsNotFound(err) || (err == nil && ipAddr == nil) {
+				log.V(3).Info("IP address claim is not fulfilled yet", "name", client.ObjectKeyFromObject(ipClaim).String())
+				pendingIPAddressClaims = append(pendingIPAddressClaims, ipClaim.Name)
+				continue
 			}
-		} else if apierrors.IsNotFound(err) {
-			if networkRef.IPAMRef == nil {
-				return nil, nil, errors.New("ipamRef of an ipamConfig is not set")
+			if err != nil {
+				return nil, nil, nil, err
 			}
-			log.V(3).Info("creating IP address claim", "name", ipAddrClaimKey.String())
-			apiGroup := networkRef.IPAMRef.APIGroup
-			ipClaim = &capiv1beta2.IPAddressClaim{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      ipAddrClaimKey.Name,
-					Namespace: ipAddrClaimKey.Namespace,
-					Labels: map[string]string{
-						LabelKeyServerClaimName:      ironcoremetalmachine.Name,
-						LabelKeyServerClaimNamespace: ironcoremetalmachine.Namespace,
-					},
-				},
-				Spec: capiv1beta2.IPAddressClaimSpec{
-					PoolRef: capiv1beta2.IPPoolReference{
-						APIGroup: apiGroup,
-						Kind:     networkRef.IPAMRef.Kind,
-						Name:     networkRef.IPAMRef.Name,
-					},
-				},
+			ipAddrCopy := ipAddr.DeepCopy()
+			if err := controllerutil.SetOwnerReference(ironcoremetalmachine, ipAddr, r.Client.Scheme()); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to set OwnerReference: %w", err)
 			}
-			if err = r.Create(ctx, ipClaim); err != nil {
-				return nil, nil, fmt.Errorf("error creating IP: %w", err)
+			if err := r.Patch(ctx, ipAddr, client.MergeFrom(ipAddrCopy)); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to patch IPAddress: %w", err)
 			}
 
-			// Wait for the IP address claim to reach the ready state
-			err = wait.PollUntilContextTimeout(
-				ctx,
-				time.Millisecond*50,
-				time.Millisecond*340,
-				true,
-				func(ctx context.Context) (bool, error) {
-					if err = r.Get(ctx, ipAddrClaimKey, ipClaim); err != nil && !apierrors.IsNotFound(err) {
-						return false, err
-					}
-					return ipClaim.Status.AddressRef.Name != "", nil
-				})
+			ipAddrServices, err := r.ipAddressNetworkServices(ctx, log, ipAddr)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
-		}
 
-		ipAddrKey := client.ObjectKey{Namespace: ipClaim.Namespace, Name: ipClaim.Status.AddressRef.Name}
-		ipAddr := &capiv1beta2.IPAddress{}
-		if err := r.Get(ctx, ipAddrKey, ipAddr); err != nil {
-			return nil, nil, err
+			IPAddressClaims = append(IPAddressClaims, ipClaim)
+			addresses = append(addresses, ipAddressMetadata(ipAddr))
+			services = services.union(ipAddrServices)
 		}
-		ipAddrCopy := ipAddr.DeepCopy()
-		if err := controllerutil.SetOwnerReference(ironcoremetalmachine, ipAddr, r.Client.Scheme()); err != nil {
-			return nil, nil, fmt.Errorf("failed to set OwnerReference: %w", err)
+
+		if len(addresses) == 0 {
+			continue
 		}
-		if err := r.Patch(ctx, ipAddr, client.MergeFrom(ipAddrCopy)); err != nil {
-			return nil, nil, fmt.Errorf("failed to patch IPAddress: %w", err)
+		first, _ := addresses[0].(map[string]any)
+		networkMetadata := map[string]any{
+			"ip":        first["ip"],
+			"prefix":    first["prefix"],
+			"gateway":   first["gateway"],
+			"addresses": addresses,
 		}
+		services.addToMetadata(networkMetadata)
+		IPAddressesMetadata[networkRef.MetadataKey] = networkMetadata
+	}
+	return IPAddressClaims, IPAddressesMetadata, pendingIPAddressClaims, nil
+}
+
+// findIPAddressClaim returns the IPAddressClaim of the IroncoreMetalMachine for the address with the given index of a
+// MetadataKey or nil if it does not exist. IPAddressClaims are looked up by their labels and must be owned by the
+// IroncoreMetalMachine. IPAddressClaims created before the labels and the owner reference were introduced are looked up
+// by their legacy name and adopted if they were created for the IroncoreMetalMachine.
+func (r *IroncoreMetalMachineReconciler) findIPAddressClaim(ctx context.Context, ironcoremetalmachine *infrav1alpha1.IroncoreMetalMachine, metadataKey string, index int) (*capiv1beta2.IPAddressClaim, error) {
+	ipAddressClaims := &capiv1beta2.IPAddressClaimList{}
+	if err := r.List(ctx, ipAddressClaims, client.InNamespace(ironcoremetalmachine.Namespace), client.MatchingLabels(ipAddressClaimLabels(ironcoremetalmachine, metadataKey, index))); err != nil {
+		return nil, fmt.Errorf("failed to list IPAddressClaims: %w", err)
+	}
 
-		IPAddressClaims = append(IPAddressClaims, ipClaim)
-		IPAddressesMetadata[networkRef.MetadataKey] = map[string]any{
-			"ip":      ipAddr.Spec.Address,
-			"prefix":  ipAddr.Spec.Prefix,
-			"gateway": ipAddr.Spec.Gateway,
+	var owned []*capiv1beta2.IPAddressClaim
+	for i := range ipAddressClaims.Items {
+		ipClaim := &ipAddressClaims.Items[i]
+		isOwned, err := controllerutil.HasOwnerReference(ipClaim.OwnerReferences, ironcoremetalmachine, r.Client.Scheme())
+		if err != nil {
+			return nil, err
+		}
+		if isOwned {
+			owned = append(owned, ipClaim)
 		}
 	}
-	return IPAddressClaims, IPAddressesMetadata, nil
+	switch len(owned) {
+	case 0:
+	case 1:
+		return owned[0], nil
+	default:
+		return nil, fmt.Errorf("found %d IPAddressClaims for address %d of metadata key %q", len(owned), index, metadataKey)
+	}
+
+	ipClaim := &capiv1beta2.IPAddressClaim{}
+	ipAddrClaimKey := client.ObjectKey{Namespace: ironcoremetalmachine.Namespace, Name: legacyIPAddressClaimName(ironcoremetalmachine.Name, metadataKey, index)}
+	if err := r.Get(ctx, ipAddrClaimKey, ipClaim); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if ipClaim.Labels[LabelKeyServerClaimName] != ironcoremetalmachine.Name || ipClaim.Labels[LabelKeyServerClaimNamespace] != ironcoremetalmachine.Namespace {
+		// the legacy name collides with the one of another IroncoreMetalMachine, a new IPAddressClaim is created
+		return nil, nil
+	}
+
+	ipClaimCopy := ipClaim.DeepCopy()
+	maps.Copy(ipClaim.Labels, ipAddressClaimLabels(ironcoremetalmachine, metadataKey, index))
+	if err := controllerutil.SetOwnerReference(ironcoremetalmachine, ipClaim, r.Client.Scheme()); err != nil {
+		return nil, fmt.Errorf("failed to set OwnerReference: %w", err)
+	}
+	if err := r.Patch(ctx, ipClaim, client.MergeFrom(ipClaimCopy)); err != nil {
+		return nil, fmt.Errorf("failed to adopt IPAddressClaim: %w", err)
+	}
+	return ipClaim, nil
 }
 
-func (r *IroncoreMetalMachineReconciler) applyIgnitionSecret(ctx context.Context, log *logr.Logger, capidatasecret *corev1.Secret, ignition []byte) (*corev1.Secret, error) {
+// applyIgnitionSecret creates or updates the ignition secret of the IroncoreMetalMachine next to its ServerClaim. It is
+// annotated with the hash of the ignition and owned by the IroncoreMetalMachine unless it is in a remote cluster.
+func (r *IroncoreMetalMachineReconciler) applyIgnitionSecret(ctx context.Context, machineScope *scope.MachineScope, capidatasecret *corev1.Secret, ignition []byte) (*corev1.Secret, error) {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
 	secretObj := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("ignition-%s", capidatasecret.Name),
-			Namespace: capidatasecret.Namespace,
-		},
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "Secret",
-			APIVersion: corev1.SchemeGroupVersion.String(),
-		},
-		Data: map[string][]byte{
-			DefaultIgnitionSecretKeyName: ignition,
+			Name:      ignitionSecretName(machineScope),
+			Namespace: machineScope.MetalNamespace,
 		},
 	}
 
-	if err := controllerutil.SetControllerReference(capidatasecret, secretObj, r.Client.Scheme()); err != nil {
-		return nil, fmt.Errorf("failed to set ControllerReference: %w", err)
-	}
-
-	opResult, err := controllerutil.CreateOrPatch(ctx, r.Client, secretObj, nil)
+	opResult, err := controllerutil.CreateOrPatch(ctx, machineScope.MetalClient, secretObj, func() error {
+		if !secretObj.CreationTimestamp.IsZero() && !ownsMetalObject(machineScope, secretObj) {
+			return fmt.Errorf("ignition secret %s belongs to another IroncoreMetalMachine", secretObj.Name)
+		}
+		secretObj.Data = map[string][]byte{
+			DefaultIgnitionSecretKeyName: ignition,
+		}
+		if format, ok := capidatasecret.Data[bootstrapFormatKey]; ok {
+			secretObj.Data[bootstrapFormatKey] = format
+		}
+		metav1.SetMetaDataAnnotation(&secretObj.ObjectMeta, infrav1alpha1.IgnitionHashAnnotation, ignitionHash(ignition))
+		// take over an ignition secret which was named after and owned by the bootstrap data secret before
+		secretObj.OwnerReferences = slices.DeleteFunc(secretObj.OwnerReferences, func(ref metav1.OwnerReference) bool {
+			return ptr.Deref(ref.Controller, false) && ref.UID != ironcoremetalmachine.UID
+		})
+		if machineScope.RemoteMetalCluster() {
+			for key, value := range metalObjectLabels(ironcoremetalmachine) {
+				metav1.SetMetaDataLabel(&secretObj.ObjectMeta, key, value)
+			}
+			return nil
+		}
+		if err := controllerutil.SetControllerReference(ironcoremetalmachine, secretObj, r.Client.Scheme()); err != nil {
+			return fmt.Errorf("failed to set ControllerReference: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create or patch the IgnitionSecret: %w", err)
 	}
-	log.Info("Created or Patched IgnitionSecret", "IgnitionSecret", secretObj.Name, "Operation", opResult)
+	machineScope.Info("Created or Patched IgnitionSecret", "IgnitionSecret", secretObj.Name, "Operation", opResult)
 
 	return secretObj, nil
 }
 
-func (r *IroncoreMetalMachineReconciler) applyServerClaim(ctx context.Context, log *logr.Logger, ironcoremetalmachine *infrav1alpha1.IroncoreMetalMachine, ignitionsecret *corev1.Secret) (*metalv1alpha1.ServerClaim, error) {
+// applyServerClaim creates the ServerClaim of the IroncoreMetalMachine. The power state and the ignition secret
+// reference are kept up to date, the remaining spec is only set on creation. The ServerClaim is bound to serverRef
+// instead of selecting a Server if it is set. A ServerClaim in a remote cluster is not owned by the
+// IroncoreMetalMachine, it is deleted explicitly together with the IroncoreMetalMachine.
+func (r *IroncoreMetalMachineReconciler) applyServerClaim(ctx context.Context, machineScope *scope.MachineScope, serverRef *corev1.LocalObjectReference, serverSelector *metav1.LabelSelector, ignitionSecretName string, power metalv1alpha1.Power) (*metalv1alpha1.ServerClaim, error) {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
 	serverClaimObj := &metalv1alpha1.ServerClaim{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      ironcoremetalmachine.Name,
-			Namespace: ironcoremetalmachine.Namespace,
-		},
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: metalv1alpha1.GroupVersion.String(),
-			Kind:       "ServerClaim",
+			Name:      serverClaimKey(machineScope).Name,
+			Namespace: machineScope.MetalNamespace,
 		},
-		Spec: metalv1alpha1.ServerClaimSpec{
-			Power: metalv1alpha1.PowerOn,
-			IgnitionSecretRef: &corev1.LocalObjectReference{
-				Name: ignitionsecret.Name,
-			},
-			Image:          ironcoremetalmachine.Spec.Image,
-			ServerSelector: ironcoremetalmachine.Spec.ServerSelector,
-			Tolerations:    ironcoremetalmachine.Spec.Tolerations,
-		},
-	}
-
-	if err := controllerutil.SetControllerReference(ironcoremetalmachine, serverClaimObj, r.Client.Scheme()); err != nil {
-		return nil, fmt.Errorf("failed to set ControllerReference: %w", err)
 	}
 
-	opResult, err := controllerutil.CreateOrPatch(ctx, r.Client, serverClaimObj, nil)
+	opResult, err := controllerutil.CreateOrPatch(ctx, machineScope.MetalClient, serverClaimObj, func() error {
+		if !serverClaimObj.CreationTimestamp.IsZero() && !ownsMetalObject(machineScope, serverClaimObj) {
+			return fmt.Errorf("ServerClaim %s belongs to another IroncoreMetalMachine", serverClaimObj.Name)
+		}
+		if serverClaimObj.CreationTimestamp.IsZero() {
+			serverClaimObj.Spec.Image = ironcoremetalmachine.Spec.Image
+			if serverRef != nil {
+				serverClaimObj.Spec.ServerRef = serverRef
+			} else {
+				serverClaimObj.Spec.ServerSelector = serverSelector
+			}
+			serverClaimObj.Spec.Tolerations = ironcoremetalmachine.Spec.Tolerations
+		}
+		serverClaimObj.Spec.Power = power
+		serverClaimObj.Spec.IgnitionSecretRef = &corev1.LocalObjectReference{
+			Name: ignitionSecretName,
+		}
+		if machineScope.RemoteMetalCluster() {
+			for key, value := range metalObjectLabels(ironcoremetalmachine) {
+				metav1.SetMetaDataLabel(&serverClaimObj.ObjectMeta, key, value)
+			}
+			return nil
+		}
+		if err := controllerutil.SetControllerReference(ironcoremetalmachine, serverClaimObj, r.Client.Scheme()); err != nil {
+			return fmt.Errorf("failed to set ControllerReference: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create or patch ServerClaim: %w", err)
 	}
-	log.Info("Created or Patched ServerClaim", "ServerClaim", serverClaimObj.Name, "Operation", opResult)
+	machineScope.Info("Created or Patched ServerClaim", "ServerClaim", serverClaimObj.Name, "Operation", opResult)
 
 	return serverClaimObj, nil
 }
 
-func (r *IroncoreMetalMachineReconciler) patchIroncoreMetalMachineProviderID(ctx context.Context, log *logr.Logger, ironcoremetalmachine *infrav1alpha1.IroncoreMetalMachine, serverClaim *metalv1alpha1.ServerClaim) error {
-	providerID := fmt.Sprintf("metal://%s/%s", serverClaim.Namespace, serverClaim.Name)
+// getBoundServer returns the Server the ServerClaim of the IroncoreMetalMachine is bound to or nil if it is not bound.
+func (r *IroncoreMetalMachineReconciler) getBoundServer(ctx context.Context, machineScope *scope.MachineScope) (*metalv1alpha1.Server, error) {
+	serverClaim := &metalv1alpha1.ServerClaim{}
+	if err := getMetalObject(ctx, machineScope, serverClaimKey(machineScope), serverClaim); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if serverClaim.Status.Phase != metalv1alpha1.PhaseBound || serverClaim.Spec.ServerRef == nil {
+		return nil, nil
+	}
+
+	server := &metalv1alpha1.Server{}
+	if err := machineScope.MetalClient.Get(ctx, client.ObjectKey{Name: serverClaim.Spec.ServerRef.Name}, server); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return server, nil
+}
+
+func (r *IroncoreMetalMachineReconciler) patchIroncoreMetalMachineProviderID(ctx context.Context, machineScope *scope.MachineScope) error {
+	log := machineScope.Logger
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+	providerID := machineProviderID(machineScope)
 
 	patch := client.MergeFrom(ironcoremetalmachine.DeepCopy())
 	ironcoremetalmachine.Spec.ProviderID = providerID
@@ -534,9 +1264,9 @@ func (r *IroncoreMetalMachineReconciler) setServerClaimOwnership(ctx context.Con
 	return nil
 }
 
-func (r *IroncoreMetalMachineReconciler) ensureServerClaimBound(ctx context.Context, serverClaim *metalv1alpha1.ServerClaim) (bool, error) {
+func (r *IroncoreMetalMachineReconciler) ensureServerClaimBound(ctx context.Context, machineScope *scope.MachineScope, serverClaim *metalv1alpha1.ServerClaim) (bool, error) {
 	claimObj := &metalv1alpha1.ServerClaim{}
-	if err := r.Get(ctx, client.ObjectKeyFromObject(serverClaim), claimObj); err != nil {
+	if err := machineScope.MetalClient.Get(ctx, client.ObjectKeyFromObject(serverClaim), claimObj); err != nil {
 		return false, err
 	}
 
@@ -545,10 +1275,3 @@ func (r *IroncoreMetalMachineReconciler) ensureServerClaimBound(ctx context.Cont
 	}
 	return true, nil
 }
-
-func findAndReplaceIgnition(ironcoremetalmachine *infrav1alpha1.IroncoreMetalMachine, data []byte) []byte {
-	// replace $${METAL_HOSTNAME} with machine name
-	modifiedData := strings.ReplaceAll(string(data), metalHostnamePlaceholder, ironcoremetalmachine.Name)
-
-	return []byte(modifiedData)
-}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -19,6 +20,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -26,8 +29,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterapiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	capiv1beta2 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
 
 	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/scope"
 	"github.com/ironcore-dev/controller-utils/clientutils"
 	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
 )
@@ -72,7 +78,7 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 					Namespace: namespace,
 				},
 				Data: map[string][]byte{
-					bootstrapDataKey: []byte(fmt.Sprintf(`{"name": "%s"}`, metalHostnamePlaceholder)),
+					bootstrapDataKey: []byte(`{"name": "%24%24%7BMETAL_HOSTNAME%7D"}`),
 				},
 			}
 
@@ -131,7 +137,7 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 				},
 			}
 
-			metalSecretNN = types.NamespacedName{Name: fmt.Sprintf("ignition-%s", secret.Name), Namespace: namespace}
+			metalSecretNN = types.NamespacedName{Name: fmt.Sprintf("ignition-%s", metalMachine.Name), Namespace: namespace}
 
 			controllerReconciler = &IroncoreMetalMachineReconciler{
 				Client: k8sClient,
@@ -182,9 +188,8 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), serverClaim)).To(Succeed())
 				Expect(k8sClient.Delete(ctx, serverClaim)).To(Succeed())
 
-				metalSecret := &corev1.Secret{}
-				Expect(k8sClient.Get(ctx, metalSecretNN, metalSecret)).To(Succeed())
-				Expect(k8sClient.Delete(ctx, metalSecret)).To(Succeed())
+				metalSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: metalSecretNN.Name, Namespace: namespace}}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, metalSecret))).To(Succeed())
 			}
 		})
 
@@ -195,6 +200,133 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			expectIgnition(`{"name":"metal-machine"}`)
+
+			metalSecret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, metalSecretNN, metalSecret)).To(Succeed())
+			Expect(metalSecret.Annotations).To(HaveKeyWithValue(infrav1alpha1.IgnitionHashAnnotation, ignitionHash([]byte(`{"name":"metal-machine"}`))))
+			Expect(metav1.IsControlledBy(metalSecret, metalMachine)).To(BeTrue())
+		})
+
+		When("the node of the machine has joined the cluster", func() {
+			JustBeforeEach(func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				expectIgnition(`{"name":"metal-machine"}`)
+
+				Eventually(UpdateStatus(machine, func() {
+					machine.Status.NodeRef = clusterapiv1beta2.MachineNodeReference{Name: "node"}
+				})).Should(Succeed())
+			})
+
+			It("should keep the ignition secret", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				expectIgnition(`{"name":"metal-machine"}`)
+			})
+
+			When("the IgnitionSecretPolicy is Delete", func() {
+				BeforeEach(func() {
+					metalMachine.Spec.IgnitionSecretPolicy = infrav1alpha1.IgnitionSecretPolicyDelete
+				})
+
+				It("should delete the ignition secret", func() {
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(Get(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: metalSecretNN.Name, Namespace: namespace}})).Should(Satisfy(apierrors.IsNotFound))
+					Expect(get(metalMachine)).To(Succeed())
+					Expect(conditions.IsTrue(metalMachine, infrav1alpha1.IgnitionReadyCondition)).To(BeTrue())
+					Expect(conditions.GetReason(metalMachine, infrav1alpha1.IgnitionReadyCondition)).To(Equal(infrav1alpha1.IgnitionDeletedReason))
+				})
+			})
+
+			When("the IgnitionSecretPolicy is Redact", func() {
+				BeforeEach(func() {
+					metalMachine.Spec.IgnitionSecretPolicy = infrav1alpha1.IgnitionSecretPolicyRedact
+				})
+
+				It("should remove the ignition and keep its hash", func() {
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					metalSecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, metalSecretNN, metalSecret)).To(Succeed())
+					Expect(metalSecret.Data).To(BeEmpty())
+					Expect(metalSecret.Annotations).To(HaveKeyWithValue(infrav1alpha1.IgnitionHashAnnotation, ignitionHash([]byte(`{"name":"metal-machine"}`))))
+					Expect(get(metalMachine)).To(Succeed())
+					Expect(conditions.GetReason(metalMachine, infrav1alpha1.IgnitionReadyCondition)).To(Equal(infrav1alpha1.IgnitionRedactedReason))
+				})
+			})
+		})
+
+		It("should report a drift of the provisioned machine instead of changing the ignition", func() {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(metalMachine),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			serverClaim := &metalv1alpha1.ServerClaim{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), serverClaim)).To(Succeed())
+			Eventually(UpdateStatus(serverClaim, func() {
+				serverClaim.Status.Phase = metalv1alpha1.PhaseBound
+			})).Should(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(metalMachine),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(get(metalMachine)).To(Succeed())
+			Expect(metalMachine.Status.Initialization.Provisioned).To(HaveValue(BeTrue()))
+			Expect(metalMachine.Status.IgnitionHash).To(Equal(ignitionHash([]byte(`{"name":"metal-machine"}`))))
+			Expect(metalMachine.Status.ServerClaimHash).NotTo(BeEmpty())
+			Expect(conditions.IsFalse(metalMachine, infrav1alpha1.DriftedCondition)).To(BeTrue())
+
+			By("Changing the metadata of the provisioned machine")
+			Eventually(Update(metalMachine, func() {
+				metalMachine.Spec.Metadata = &apiextensionsv1.JSON{Raw: []byte(`{"foo": "bar"}`)}
+			})).Should(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(metalMachine),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			expectIgnition(`{"name":"metal-machine"}`)
+			Expect(get(metalMachine)).To(Succeed())
+			Expect(conditions.IsTrue(metalMachine, infrav1alpha1.DriftedCondition)).To(BeTrue())
+			Expect(conditions.GetReason(metalMachine, infrav1alpha1.DriftedCondition)).To(Equal(infrav1alpha1.IgnitionDriftedReason))
+			Expect(metalMachine.Status.IgnitionHash).To(Equal(ignitionHash([]byte(`{"name":"metal-machine"}`))))
+		})
+
+		It("should map the watched resources to the IroncoreMetalMachine", func() {
+			By("Mapping the bootstrap secret to the IroncoreMetalMachine of the Machine")
+			Expect(controllerReconciler.bootstrapSecretToIroncoreMetalMachines(ctx, secret)).To(BeEmpty())
+			Eventually(Update(secret, func() {
+				secret.Labels = map[string]string{clusterapiv1beta2.ClusterNameLabel: cluster.Name}
+			})).Should(Succeed())
+			Expect(controllerReconciler.bootstrapSecretToIroncoreMetalMachines(ctx, secret)).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: machine.Spec.InfrastructureRef.Name}},
+			))
+
+			By("Mapping the IPAddressClaim to the IroncoreMetalMachine")
+			ipClaim := &capiv1beta2.IPAddressClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "metal-machine-foo",
+				},
+			}
+			Expect(controllerReconciler.ipAddressClaimToIroncoreMetalMachine(ctx, ipClaim)).To(BeEmpty())
+			Expect(controllerutil.SetOwnerReference(metalMachine, ipClaim, k8sClient.Scheme())).To(Succeed())
+			Expect(controllerReconciler.ipAddressClaimToIroncoreMetalMachine(ctx, ipClaim)).To(ConsistOf(
+				reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)},
+			))
 		})
 
 		When("the metadata is present in the metal machine", func() {
@@ -216,6 +348,490 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 						ign + `"},"filesystem":"root","mode":420,"path":"/var/lib/metal-cloud-config/metadata"}]}}`)
 			})
 		})
+
+		When("the bootstrap data is an Ignition 3.x config", func() {
+			BeforeEach(func() {
+				secret.Data = map[string][]byte{
+					bootstrapDataKey: []byte(`{"ignition":{"version":"3.4.0"}}`),
+				}
+				metalMachine.Spec.Metadata = &apiextensionsv1.JSON{
+					Raw: []byte(`{"foo": "bar"}`),
+				}
+			})
+
+			It("should add the metadata file without a filesystem", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				ign := base64.StdEncoding.EncodeToString([]byte(`{"foo":"bar"}`))
+				expectIgnition(
+					`{"ignition":{"version":"3.4.0"},"storage":{"files":[{"contents":{"compression":"","source":"data:;base64,` +
+						ign + `"},"mode":420,"overwrite":true,"path":"/var/lib/metal-cloud-config/metadata"}]}}`)
+			})
+		})
+
+		When("the bootstrap data is translated to Ignition 3.x", func() {
+			BeforeEach(func() {
+				secret.Data = map[string][]byte{
+					bootstrapDataKey: []byte(`{"ignition":{"version":"2.3.0"},"storage":{"files":[{"filesystem":"root","path":"/etc/foo","contents":{"source":"data:,foo"}}]}}`),
+				}
+				metalMachine.Spec.IgnitionVersion = "3.4.0"
+			})
+
+			It("should create the ignition secret with an Ignition 3.x config", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				expectIgnition(`{"ignition":{"version":"3.4.0"},"storage":{"files":[{"contents":{"source":"data:,foo"},"overwrite":true,"path":"/etc/foo"}]}}`)
+			})
+		})
+
+		When("the bootstrap data is in the cloud-config format", func() {
+			BeforeEach(func() {
+				secret.Data = map[string][]byte{
+					bootstrapDataKey:   []byte("## template: jinja\n#cloud-config\nruncmd:\n- echo $${METAL_HOSTNAME}\n"),
+					bootstrapFormatKey: []byte(cloudConfigFormat),
+				}
+				metalMachine.Spec.Metadata = &apiextensionsv1.JSON{
+					Raw: []byte(`{"foo": "bar"}`),
+				}
+			})
+
+			It("should add the hostname and the metadata file to the cloud-config", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				metadata := base64.StdEncoding.EncodeToString([]byte(`{"foo":"bar"}`))
+				expectIgnition("## template: jinja\n#cloud-config\n" +
+					"hostname: metal-machine\n" +
+					"runcmd:\n- echo metal-machine\n" +
+					"write_files:\n- content: " + metadata + "\n  encoding: b64\n  path: /var/lib/metal-cloud-config/metadata\n  permissions: \"0644\"\n")
+
+				metalSecret := &corev1.Secret{}
+				Expect(k8sClient.Get(ctx, metalSecretNN, metalSecret)).To(Succeed())
+				Expect(metalSecret.Data).To(HaveKeyWithValue(bootstrapFormatKey, []byte(cloudConfigFormat)))
+			})
+		})
+
+		When("the bootstrap data references variables", func() {
+			BeforeEach(func() {
+				secret.Data = map[string][]byte{
+					bootstrapDataKey: []byte(`{"name":"$${METAL_MACHINE_NAMESPACE}/$${METAL_CLUSTER_NAME}","zone":"$${METAL_METADATA_zone}","source":"data:,%24%24%7BMETAL_PROVIDER_ID%7D"}`),
+				}
+				metalMachine.Spec.Metadata = &apiextensionsv1.JSON{
+					Raw: []byte(`{"zone": "a\"b"}`),
+				}
+			})
+
+			It("should render the variables into the ignition", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				ign := base64.StdEncoding.EncodeToString([]byte(`{"zone":"a\"b"}`))
+				expectIgnition(
+					`{"name":"default/cluster","source":"data:,metal:%2F%2Fdefault%2Fmetal-machine",` +
+						`"storage":{"files":[{"contents":{"compression":"","source":"data:;base64,` +
+						ign + `"},"filesystem":"root","mode":420,"path":"/var/lib/metal-cloud-config/metadata"}]},"zone":"a\"b"}`)
+				Eventually(Object(metalMachine)).Should(Satisfy(func(m *infrav1alpha1.IroncoreMetalMachine) bool {
+					return conditions.IsTrue(m, infrav1alpha1.IgnitionReadyCondition)
+				}))
+			})
+		})
+
+		When("the ServerClaims are managed in a remote metal cluster", func() {
+			var metalNamespace *corev1.Namespace
+
+			BeforeEach(func() {
+				secret.Data = map[string][]byte{
+					bootstrapDataKey: []byte(`{"source":"data:,%24%24%7BMETAL_PROVIDER_ID%7D"}`),
+				}
+
+				kubeconfigSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						GenerateName: "metal-kubeconfig-",
+						Namespace:    namespace,
+					},
+					Data: map[string][]byte{infrav1alpha1.MetalKubeconfigSecretKey: envtestKubeconfig()},
+				}
+				Expect(k8sClient.Create(ctx, kubeconfigSecret)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, kubeconfigSecret)
+				metalNamespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "metal-"}}
+				Expect(k8sClient.Create(ctx, metalNamespace)).To(Succeed())
+
+				metalCluster.Spec.MetalKubeconfigSecretRef = &infrav1alpha1.MetalKubeconfigSecretReference{
+					Name:            kubeconfigSecret.Name,
+					TargetNamespace: metalNamespace.Name,
+				}
+				controllerReconciler.MetalClusters = &MetalClusters{}
+			})
+
+			It("should reference the remote ServerClaim in the ProviderID and the rendered variable", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				serverClaims := &metalv1alpha1.ServerClaimList{}
+				Expect(k8sClient.List(ctx, serverClaims, client.InNamespace(metalNamespace.Name))).To(Succeed())
+				Expect(serverClaims.Items).To(HaveLen(1))
+				serverClaim := &serverClaims.Items[0]
+				Expect(serverClaim.Labels).To(HaveKeyWithValue(LabelKeyServerClaimNamespace, namespace))
+				providerID := fmt.Sprintf("metal://%s/%s", metalNamespace.Name, serverClaim.Name)
+
+				ignitionSecret := &corev1.Secret{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: metalNamespace.Name, Name: serverClaim.Spec.IgnitionSecretRef.Name}, ignitionSecret)).To(Succeed())
+				Expect(string(ignitionSecret.Data[DefaultIgnitionSecretKeyName])).To(
+					Equal(fmt.Sprintf(`{"source":"data:,%s"}`, strings.ReplaceAll(providerID, "/", "%2F"))))
+
+				serverClaim.Status.Phase = metalv1alpha1.PhaseBound
+				Expect(k8sClient.Status().Update(ctx, serverClaim)).To(Succeed())
+				By("Waiting for the cache of the metal cluster to observe the binding")
+				Eventually(func(g Gomega) {
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), metalMachine)).To(Succeed())
+					g.Expect(metalMachine.Spec.ProviderID).To(Equal(providerID))
+				}).Should(Succeed())
+				Expect(controllerReconciler.metalServerClaimToIroncoreMetalMachine(ctx, serverClaim)).To(ConsistOf(
+					reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)}))
+
+				Expect(clientutils.PatchRemoveFinalizer(ctx, k8sClient, metalMachine, IroncoreMetalMachineFinalizer)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, metalMachine)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, serverClaim)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, ignitionSecret)).To(Succeed())
+			})
+		})
+
+		When("the bootstrap data references an unknown variable", func() {
+			BeforeEach(func() {
+				secret.Data = map[string][]byte{
+					bootstrapDataKey: []byte(`{"name":"$${METAL_UNKNOWN}"}`),
+				}
+			})
+
+			It("should report the unresolved variable instead of creating the ignition secret", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(Object(metalMachine)).Should(Satisfy(func(m *infrav1alpha1.IroncoreMetalMachine) bool {
+					condition := conditions.Get(m, infrav1alpha1.IgnitionReadyCondition)
+					return condition != nil && condition.Reason == infrav1alpha1.UnresolvedVariablesReason &&
+						condition.Message == "unresolved variables in bootstrap data: METAL_UNKNOWN"
+				}))
+				Expect(k8sClient.Get(ctx, metalSecretNN, &corev1.Secret{})).To(Satisfy(apierrors.IsNotFound))
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), &metalv1alpha1.ServerClaim{})).To(Satisfy(apierrors.IsNotFound))
+
+				Expect(clientutils.PatchRemoveFinalizer(ctx, k8sClient, metalMachine, IroncoreMetalMachineFinalizer)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, metalMachine)).To(Succeed())
+				Eventually(Get(metalMachine)).Should(Satisfy(apierrors.IsNotFound))
+			})
+		})
+
+		When("the bootstrap data references variables of the Server", func() {
+			var server *metalv1alpha1.Server
+
+			BeforeEach(func() {
+				secret.Data = map[string][]byte{
+					bootstrapDataKey: []byte(`{"name":"$${METAL_SERVER_NAME}","rack":"$${METAL_SERVER_LABEL_metal.ironcore.dev/rack}"}`),
+				}
+				server = &metalv1alpha1.Server{
+					ObjectMeta: metav1.ObjectMeta{
+						GenerateName: "server-",
+						Labels:       map[string]string{"metal.ironcore.dev/rack": "r1"},
+					},
+					Spec: metalv1alpha1.ServerSpec{
+						SystemUUID: "38947555-7742-3448-3784-823347823835",
+					},
+				}
+			})
+
+			AfterEach(func() {
+				Expect(k8sClient.Delete(ctx, server)).To(Succeed())
+			})
+
+			It("should keep the Server powered off until the variables are resolved", func() {
+				Expect(k8sClient.Create(ctx, server)).To(Succeed())
+
+				result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+
+				serverClaim := &metalv1alpha1.ServerClaim{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), serverClaim)).To(Succeed())
+				Expect(serverClaim.Spec.Power).To(Equal(metalv1alpha1.PowerOff))
+				Expect(serverClaim.Spec.IgnitionSecretRef).To(Equal(&corev1.LocalObjectReference{Name: metalSecretNN.Name}))
+				Expect(k8sClient.Get(ctx, metalSecretNN, &corev1.Secret{})).To(Satisfy(apierrors.IsNotFound))
+
+				By("Binding the ServerClaim to the Server")
+				Eventually(Update(serverClaim, func() {
+					serverClaim.Spec.ServerRef = &corev1.LocalObjectReference{Name: server.Name}
+				})).Should(Succeed())
+				Eventually(UpdateStatus(serverClaim, func() {
+					serverClaim.Status.Phase = metalv1alpha1.PhaseBound
+				})).Should(Succeed())
+
+				_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				expectIgnition(fmt.Sprintf(`{"name":"%s","rack":"r1"}`, server.Name))
+				Eventually(Object(serverClaim)).Should(HaveField("Spec.Power", metalv1alpha1.PowerOn))
+			})
+		})
+
+		When("the ServerClaim is bound to a Server", func() {
+			var server *metalv1alpha1.Server
+
+			BeforeEach(func() {
+				server = &metalv1alpha1.Server{
+					ObjectMeta: metav1.ObjectMeta{
+						GenerateName: "server-",
+					},
+					Spec: metalv1alpha1.ServerSpec{
+						SystemUUID: "38947555-7742-3448-3784-823347823836",
+					},
+				}
+			})
+
+			AfterEach(func() {
+				Expect(k8sClient.Delete(ctx, server)).To(Succeed())
+			})
+
+			bindServerClaim := func() *metalv1alpha1.ServerClaim {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				serverClaim := &metalv1alpha1.ServerClaim{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), serverClaim)).To(Succeed())
+				Eventually(Update(serverClaim, func() {
+					serverClaim.Spec.ServerRef = &corev1.LocalObjectReference{Name: server.Name}
+				})).Should(Succeed())
+				Eventually(UpdateStatus(serverClaim, func() {
+					serverClaim.Status.Phase = metalv1alpha1.PhaseBound
+				})).Should(Succeed())
+				Eventually(Update(server, func() {
+					server.Spec.ServerClaimRef = &metalv1alpha1.ImmutableObjectReference{Namespace: namespace, Name: serverClaim.Name}
+				})).Should(Succeed())
+				return serverClaim
+			}
+
+			It("should power off the Server as requested by the power annotation", func() {
+				Expect(k8sClient.Create(ctx, server)).To(Succeed())
+				Eventually(UpdateStatus(server, func() {
+					server.Status.PowerState = metalv1alpha1.ServerOffPowerState
+				})).Should(Succeed())
+				Eventually(Update(metalMachine, func() {
+					metalMachine.Annotations = map[string]string{infrav1alpha1.PowerAnnotation: string(metalv1alpha1.PowerOff)}
+				})).Should(Succeed())
+
+				serverClaim := bindServerClaim()
+				Expect(serverClaim.Spec.Power).To(Equal(metalv1alpha1.PowerOff))
+
+				result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{}))
+				Eventually(Object(metalMachine)).Should(HaveField("Status.PowerState", metalv1alpha1.ServerOffPowerState))
+				Expect(conditions.GetReason(metalMachine, infrav1alpha1.ServerReadyCondition)).To(Equal(infrav1alpha1.ServerPoweredOffReason))
+
+				By("Mapping the Server to the IroncoreMetalMachine")
+				Expect(controllerReconciler.serverToIroncoreMetalMachine(ctx, server)).To(ConsistOf(
+					reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)},
+				))
+
+				By("Powering the Server on once the annotation is removed")
+				Eventually(Update(metalMachine, func() {
+					delete(metalMachine.Annotations, infrav1alpha1.PowerAnnotation)
+				})).Should(Succeed())
+				_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(Object(serverClaim)).Should(HaveField("Spec.Power", metalv1alpha1.PowerOn))
+			})
+
+			It("should pass the requested operation to the Server", func() {
+				Expect(k8sClient.Create(ctx, server)).To(Succeed())
+				Eventually(UpdateStatus(server, func() {
+					server.Status.PowerState = metalv1alpha1.ServerOnPowerState
+				})).Should(Succeed())
+				Eventually(Update(metalMachine, func() {
+					metalMachine.Annotations = map[string]string{infrav1alpha1.ServerOperationAnnotation: metalv1alpha1.GracefulRestartServerPower}
+				})).Should(Succeed())
+
+				bindServerClaim()
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(Object(server)).Should(HaveField("Annotations",
+					HaveKeyWithValue(metalv1alpha1.OperationAnnotation, metalv1alpha1.GracefulRestartServerPower)))
+				Eventually(Object(metalMachine)).Should(SatisfyAll(
+					HaveField("Annotations", Not(HaveKey(infrav1alpha1.ServerOperationAnnotation))),
+					HaveField("Status.PowerState", metalv1alpha1.ServerOnPowerState),
+				))
+			})
+
+			When("the IroncoreMetalMachine has ServerAffinity", func() {
+				BeforeEach(func() {
+					machine.Labels[clusterapiv1beta2.MachineDeploymentNameLabel] = "md"
+					metalMachine.Spec.ServerAffinity = true
+				})
+
+				It("should label the bound Server with the affinity group", func() {
+					Expect(k8sClient.Create(ctx, server)).To(Succeed())
+					bindServerClaim()
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(Object(server)).Should(HaveField("Labels",
+						HaveKeyWithValue(LabelKeyServerAffinity, serverAffinityGroup(machine))))
+				})
+
+				It("should claim the released Server of the affinity group by name", func() {
+					server.Labels = map[string]string{LabelKeyServerAffinity: serverAffinityGroup(machine)}
+					Expect(k8sClient.Create(ctx, server)).To(Succeed())
+					Eventually(UpdateStatus(server, func() {
+						server.Status.State = metalv1alpha1.ServerStateAvailable
+					})).Should(Succeed())
+
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					serverClaim := &metalv1alpha1.ServerClaim{}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), serverClaim)).To(Succeed())
+					Expect(serverClaim.Spec.ServerRef).To(Equal(&corev1.LocalObjectReference{Name: server.Name}))
+					Expect(serverClaim.Spec.ServerSelector).To(BeNil())
+				})
+
+				It("should not claim a Server of the affinity group which does not match the ServerSelector", func() {
+					Eventually(Update(metalMachine, func() {
+						metalMachine.Spec.ServerSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"type": "compute"}}
+					})).Should(Succeed())
+					server.Labels = map[string]string{LabelKeyServerAffinity: serverAffinityGroup(machine), "type": "storage"}
+					Expect(k8sClient.Create(ctx, server)).To(Succeed())
+					Eventually(UpdateStatus(server, func() {
+						server.Status.State = metalv1alpha1.ServerStateAvailable
+					})).Should(Succeed())
+
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					serverClaim := &metalv1alpha1.ServerClaim{}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), serverClaim)).To(Succeed())
+					Expect(serverClaim.Spec.ServerRef).To(BeNil())
+					Expect(serverClaim.Spec.ServerSelector).To(Equal(metalMachine.Spec.ServerSelector))
+				})
+			})
+		})
+
+		When("the machine is placed into a failure domain", func() {
+			BeforeEach(func() {
+				metalCluster.Spec.FailureDomains = []infrav1alpha1.FailureDomain{{
+					Name: "rack-1",
+					ServerSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"rack": "1"},
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      "room",
+							Operator: metav1.LabelSelectorOpIn,
+							Values:   []string{"a", "b"},
+						}},
+					},
+				}}
+				metalMachine.Spec.ServerSelector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{"type": "compute"},
+				}
+				machine.Spec.FailureDomain = "rack-1"
+			})
+
+			It("should add the ServerSelector of the failure domain to the ServerClaim", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				serverClaim := &metalv1alpha1.ServerClaim{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), serverClaim)).To(Succeed())
+				Expect(serverClaim.Spec.ServerSelector).To(Equal(&metav1.LabelSelector{
+					MatchLabels: map[string]string{"type": "compute", "rack": "1"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "room",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"a", "b"},
+					}},
+				}))
+			})
+		})
+
+		When("a kube-vip control plane load balancer is configured", func() {
+			BeforeEach(func() {
+				metalCluster.Spec.ControlPlaneEndpoint.Port = 6443
+				metalCluster.Spec.ControlPlaneLoadBalancer = &infrav1alpha1.ControlPlaneLoadBalancer{
+					Type:      infrav1alpha1.ControlPlaneLoadBalancerKubeVIP,
+					Interface: "eth0",
+				}
+			})
+
+			It("should not add the kube-vip manifest to the ignition of a worker machine", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				expectIgnition(`{"name":"metal-machine"}`)
+			})
+
+			When("the machine is a control plane machine", func() {
+				BeforeEach(func() {
+					machine.Labels[clusterapiv1beta2.MachineControlPlaneLabel] = ""
+				})
+
+				It("should add the kube-vip manifest to the ignition", func() {
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					files, err := controlPlaneLoadBalancerFiles(metalCluster, false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(files).To(HaveLen(1))
+					Expect(files[0].Path).To(Equal("/etc/kubernetes/manifests/kube-vip.yaml"))
+					Expect(string(files[0].Data)).To(ContainSubstring("value: " + testHost))
+					Expect(string(files[0].Data)).To(ContainSubstring("value: eth0"))
+
+					manifest := base64.StdEncoding.EncodeToString(files[0].Data)
+					expectIgnition(
+						`{"name":"metal-machine","storage":{"files":[{"contents":{"compression":"","source":"data:;base64,` +
+							manifest + `"},"filesystem":"root","mode":420,"path":"/etc/kubernetes/manifests/kube-vip.yaml"}]}}`)
+				})
+			})
+		})
+
 		When("delete machine", func() {
 			It("should delete", func() {
 				Expect(k8sClient.Delete(ctx, metalMachine)).To(Succeed())
@@ -228,6 +844,241 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 				Expect(err).To(HaveOccurred())
 				Expect(apierrors.IsNotFound(err)).To(BeTrue())
 			})
+
+			It("should delete the ServerClaim and the ignition secret before removing the finalizer", func() {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+				Expect(err).NotTo(HaveOccurred())
+
+				serverClaim := &metalv1alpha1.ServerClaim{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), serverClaim)).To(Succeed())
+
+				Expect(k8sClient.Delete(ctx, metalMachine)).To(Succeed())
+
+				By("Requeueing while the ServerClaim is deleted")
+				result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(infrav1alpha1.DefaultReconcilerRequeue))
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), metalMachine)).To(Succeed())
+				Expect(metalMachine.Finalizers).To(ContainElement(IroncoreMetalMachineFinalizer))
+				Eventually(Get(serverClaim)).Should(Satisfy(apierrors.IsNotFound))
+
+				By("Removing the finalizer once the ServerClaim is gone")
+				_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(Get(metalMachine)).Should(Satisfy(apierrors.IsNotFound))
+				Eventually(Get(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: metalSecretNN.Name, Namespace: namespace}})).Should(Satisfy(apierrors.IsNotFound))
+			})
+
+			When("the ServerClaim is bound to a Server which is still powered on", func() {
+				var server *metalv1alpha1.Server
+
+				BeforeEach(func() {
+					server = &metalv1alpha1.Server{
+						ObjectMeta: metav1.ObjectMeta{
+							GenerateName: "server-",
+						},
+						Spec: metalv1alpha1.ServerSpec{
+							SystemUUID: "38947555-7742-3448-3784-823347823834",
+						},
+					}
+				})
+
+				AfterEach(func() {
+					Expect(k8sClient.Delete(ctx, server)).To(Succeed())
+				})
+
+				It("should wait for the Server to be released and powered off", func() {
+					Expect(k8sClient.Create(ctx, server)).To(Succeed())
+					Eventually(UpdateStatus(server, func() {
+						server.Status.State = metalv1alpha1.ServerStateReserved
+						server.Status.PowerState = metalv1alpha1.ServerOnPowerState
+					})).Should(Succeed())
+
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+					Expect(err).NotTo(HaveOccurred())
+
+					serverClaim := &metalv1alpha1.ServerClaim{}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), serverClaim)).To(Succeed())
+					Eventually(Update(serverClaim, func() {
+						serverClaim.Spec.ServerRef = &corev1.LocalObjectReference{Name: server.Name}
+					})).Should(Succeed())
+
+					Expect(k8sClient.Delete(ctx, metalMachine)).To(Succeed())
+					_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(Get(serverClaim)).Should(Satisfy(apierrors.IsNotFound))
+
+					By("Keeping the finalizer while the Server is powered on")
+					result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(Equal(infrav1alpha1.DefaultReconcilerRequeue))
+					Eventually(Object(metalMachine)).Should(SatisfyAll(
+						HaveField("Finalizers", ContainElement(IroncoreMetalMachineFinalizer)),
+						HaveField("Status.ServerRef.Name", Equal(server.Name)),
+					))
+
+					By("Removing the finalizer once the Server is released")
+					Eventually(UpdateStatus(server, func() {
+						server.Status.State = metalv1alpha1.ServerStateAvailable
+						server.Status.PowerState = metalv1alpha1.ServerOffPowerState
+					})).Should(Succeed())
+					_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(Get(metalMachine)).Should(Satisfy(apierrors.IsNotFound))
+				})
+			})
+
+			When("the DeletionPolicy requests a ServerMaintenance", func() {
+				var (
+					server      *metalv1alpha1.Server
+					serverClaim *metalv1alpha1.ServerClaim
+					maintenance *metalv1alpha1.ServerMaintenance
+				)
+
+				BeforeEach(func() {
+					server = &metalv1alpha1.Server{
+						ObjectMeta: metav1.ObjectMeta{
+							GenerateName: "server-",
+						},
+						Spec: metalv1alpha1.ServerSpec{
+							SystemUUID: "38947555-7742-3448-3784-823347823834",
+						},
+					}
+					maintenance = &metalv1alpha1.ServerMaintenance{}
+				})
+
+				JustBeforeEach(func() {
+					Expect(k8sClient.Create(ctx, server)).To(Succeed())
+					Eventually(UpdateStatus(server, func() {
+						server.Status.State = metalv1alpha1.ServerStateReserved
+						server.Status.PowerState = metalv1alpha1.ServerOnPowerState
+					})).Should(Succeed())
+
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+					Expect(err).NotTo(HaveOccurred())
+
+					serverClaim = &metalv1alpha1.ServerClaim{}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), serverClaim)).To(Succeed())
+					Eventually(Update(serverClaim, func() {
+						serverClaim.Spec.ServerRef = &corev1.LocalObjectReference{Name: server.Name}
+					})).Should(Succeed())
+
+					Expect(k8sClient.Delete(ctx, metalMachine)).To(Succeed())
+					result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.RequeueAfter).To(Equal(infrav1alpha1.DefaultReconcilerRequeue))
+
+					Expect(get(metalMachine)).To(Succeed())
+					Expect(metalMachine.Status.ServerMaintenanceRef).NotTo(BeNil())
+					maintenance.Name = metalMachine.Status.ServerMaintenanceRef.Name
+					maintenance.Namespace = namespace
+					Expect(get(maintenance)).To(Succeed())
+					Expect(maintenance.Spec.Policy).To(Equal(metalv1alpha1.ServerMaintenancePolicyEnforced))
+					Expect(maintenance.Spec.ServerRef.Name).To(Equal(server.Name))
+				})
+
+				AfterEach(func() {
+					Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, maintenance))).To(Succeed())
+					Expect(k8sClient.Delete(ctx, server)).To(Succeed())
+				})
+
+				When("the DeletionPolicy is ReleaseAfterDiskWipe", func() {
+					BeforeEach(func() {
+						metalMachine.Spec.DeletionPolicy = infrav1alpha1.DeletionPolicyReleaseAfterDiskWipe
+						controllerReconciler.DiskWipeImage = "ghcr.io/ironcore-dev/os-images/disk-wipe:latest"
+					})
+
+					It("should keep the ServerClaim until the disk wipe is finished", func() {
+						Expect(maintenance.Spec.ServerPower).To(Equal(metalv1alpha1.PowerOn))
+						Expect(maintenance.Spec.ServerBootConfigurationTemplate).To(HaveField("Spec.Image", controllerReconciler.DiskWipeImage))
+
+						By("Waiting while the disk wipe is not completed")
+						_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(get(serverClaim)).To(Succeed())
+						Expect(get(maintenance)).To(Succeed())
+						Expect(get(metalMachine)).To(Succeed())
+						Expect(conditions.Get(metalMachine, infrav1alpha1.ServerReadyCondition)).To(HaveField("Reason", infrav1alpha1.WaitingForDiskWipeReason))
+
+						By("Removing the ServerMaintenance once the disk wipe is completed")
+						Eventually(Update(maintenance, func() {
+							metav1.SetMetaDataAnnotation(&maintenance.ObjectMeta, infrav1alpha1.DiskWipeCompletedAnnotation, "true")
+						})).Should(Succeed())
+						_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+						Expect(err).NotTo(HaveOccurred())
+						Eventually(Get(maintenance)).Should(Satisfy(apierrors.IsNotFound))
+
+						By("Deleting the ServerClaim once the ServerMaintenance is removed")
+						_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+						Expect(err).NotTo(HaveOccurred())
+						Eventually(Get(serverClaim)).Should(Satisfy(apierrors.IsNotFound))
+
+						By("Removing the finalizer once the Server is released")
+						Eventually(UpdateStatus(server, func() {
+							server.Status.State = metalv1alpha1.ServerStateAvailable
+							server.Status.PowerState = metalv1alpha1.ServerOffPowerState
+						})).Should(Succeed())
+						_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+						Expect(err).NotTo(HaveOccurred())
+						Eventually(Get(metalMachine)).Should(Satisfy(apierrors.IsNotFound))
+					})
+				})
+
+				When("the disk wipe of the ReleaseAfterDiskWipe DeletionPolicy times out", func() {
+					BeforeEach(func() {
+						metalMachine.Spec.DeletionPolicy = infrav1alpha1.DeletionPolicyReleaseAfterDiskWipe
+						controllerReconciler.DiskWipeTimeout = time.Nanosecond
+						DeferCleanup(func() {
+							controllerReconciler.DiskWipeTimeout = 0
+						})
+					})
+
+					It("should report the timeout and keep the Server in maintenance", func() {
+						Eventually(UpdateStatus(server, func() {
+							server.Status.State = metalv1alpha1.ServerStateMaintenance
+						})).Should(Succeed())
+
+						_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(get(metalMachine)).To(Succeed())
+						Expect(conditions.Get(metalMachine, infrav1alpha1.ServerReadyCondition)).To(HaveField("Reason", infrav1alpha1.DiskWipeTimedOutReason))
+						Eventually(Get(serverClaim)).Should(Satisfy(apierrors.IsNotFound))
+
+						_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+						Expect(err).NotTo(HaveOccurred())
+						Eventually(Get(metalMachine)).Should(Satisfy(apierrors.IsNotFound))
+						Expect(get(maintenance)).To(Succeed())
+						Expect(maintenance.Annotations).NotTo(HaveKey(infrav1alpha1.DiskWipeCompletedAnnotation))
+					})
+				})
+
+				When("the DeletionPolicy is KeepForInspection", func() {
+					BeforeEach(func() {
+						metalMachine.Spec.DeletionPolicy = infrav1alpha1.DeletionPolicyKeepForInspection
+					})
+
+					It("should release the Server once it is in maintenance and keep the ServerMaintenance", func() {
+						Expect(maintenance.Spec.ServerPower).To(BeEmpty())
+						Expect(maintenance.Spec.ServerBootConfigurationTemplate).To(BeNil())
+
+						Eventually(UpdateStatus(maintenance, func() {
+							maintenance.Status.State = metalv1alpha1.ServerMaintenanceStateInMaintenance
+						})).Should(Succeed())
+						Eventually(UpdateStatus(server, func() {
+							server.Status.State = metalv1alpha1.ServerStateMaintenance
+						})).Should(Succeed())
+
+						_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+						Expect(err).NotTo(HaveOccurred())
+						Eventually(Get(serverClaim)).Should(Satisfy(apierrors.IsNotFound))
+
+						_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(metalMachine)})
+						Expect(err).NotTo(HaveOccurred())
+						Eventually(Get(metalMachine)).Should(Satisfy(apierrors.IsNotFound))
+						Expect(get(maintenance)).To(Succeed())
+					})
+				})
+			})
 		})
 		When("the ipam config is present in the metal machine", func() {
 			const metadataKey = "meta-key"
@@ -276,12 +1127,22 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 				}}
 
 				Expect(k8sClient.Create(ctx, ipAddress)).To(Succeed())
-				go func() {
-					defer GinkgoRecover()
-					Eventually(UpdateStatus(ipAddressClaim, func() {
-						ipAddressClaim.Status.AddressRef.Name = ipAddress.Name
-					})).Should(Succeed())
-				}()
+			})
+
+			JustBeforeEach(func() {
+				By("Waiting for the IPAddressClaim to be fulfilled")
+				result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{}))
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), metalMachine)).To(Succeed())
+				Expect(conditions.GetReason(metalMachine, infrav1alpha1.IPAddressesAllocatedCondition)).To(Equal(infrav1alpha1.WaitingForIPAMReason))
+				Expect(k8sClient.Get(ctx, metalSecretNN, &corev1.Secret{})).To(Satisfy(apierrors.IsNotFound))
+
+				Eventually(UpdateStatus(ipAddressClaim, func() {
+					ipAddressClaim.Status.AddressRef.Name = ipAddress.Name
+				})).Should(Succeed())
 			})
 
 			AfterEach(func() {
@@ -295,7 +1156,7 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 				})
 				Expect(err).NotTo(HaveOccurred())
 
-				ign := base64.StdEncoding.EncodeToString([]byte(`{"meta-key":{"gateway":"10.11.12.1","ip":"10.11.12.13","prefix":24}}`))
+				ign := base64.StdEncoding.EncodeToString([]byte(`{"meta-key":{"addresses":[{"family":"IPv4","gateway":"10.11.12.1","ip":"10.11.12.13","prefix":24}],"gateway":"10.11.12.1","ip":"10.11.12.13","prefix":24}}`))
 				expectIgnition(
 					`{"name":"metal-machine","storage":{"files":[{"contents":{"compression":"","source":"data:;base64,` +
 						ign + `"},"filesystem":"root","mode":420,"path":"/var/lib/metal-cloud-config/metadata"}]}}`)
@@ -312,6 +1173,15 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 				Eventually(Object(ipAddressClaim)).Should(SatisfyAll(
 					HaveField("Labels", HaveKeyWithValue(LabelKeyServerClaimName, serverClaim.Name)),
 					HaveField("Labels", HaveKeyWithValue(LabelKeyServerClaimNamespace, serverClaim.Namespace)),
+					HaveField("Labels", HaveKeyWithValue(LabelKeyIPAMMetadataKey, metadataKey)),
+					HaveField("OwnerReferences", ContainElement(
+						metav1.OwnerReference{
+							APIVersion: infrav1alpha1.GroupVersion.String(),
+							Kind:       ironcoreMetalMachine,
+							Name:       metalMachine.Name,
+							UID:        metalMachine.UID,
+						},
+					)),
 					HaveField("OwnerReferences", ContainElement(
 						metav1.OwnerReference{
 							APIVersion: metalv1alpha1.GroupVersion.String(),
@@ -351,7 +1221,7 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 					})
 					Expect(err).NotTo(HaveOccurred())
 
-					ign := base64.StdEncoding.EncodeToString([]byte(`{"foo":"bar","meta-key":{"gateway":"10.11.12.1","ip":"10.11.12.13","prefix":24}}`))
+					ign := base64.StdEncoding.EncodeToString([]byte(`{"foo":"bar","meta-key":{"addresses":[{"family":"IPv4","gateway":"10.11.12.1","ip":"10.11.12.13","prefix":24}],"gateway":"10.11.12.1","ip":"10.11.12.13","prefix":24}}`))
 					expectIgnition(
 						`{"name":"metal-machine","storage":{"files":[{"contents":{"compression":"","source":"data:;base64,` +
 							ign + `"},"filesystem":"root","mode":420,"path":"/var/lib/metal-cloud-config/metadata"}]}}`)
@@ -394,6 +1264,122 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 				// check status for v1beta2 contract
 				Expect(metalMachine.Status.Initialization).NotTo(BeNil())
 				Expect(*metalMachine.Status.Initialization.Provisioned).To(BeTrue())
+
+				By("Verifying the addresses of the machine")
+				Expect(metalMachine.Status.Addresses).To(Equal([]clusterapiv1beta2.MachineAddress{
+					{Type: clusterapiv1beta2.MachineHostName, Address: metalMachine.Name},
+					{Type: clusterapiv1beta2.MachineInternalIP, Address: "10.11.12.13"},
+				}))
+
+				By("Verifying the machine conditions")
+				Expect(conditions.IsTrue(metalMachine, infrav1alpha1.BootstrapDataReadyCondition)).To(BeTrue())
+				Expect(conditions.IsTrue(metalMachine, infrav1alpha1.IPAddressesAllocatedCondition)).To(BeTrue())
+				Expect(conditions.IsTrue(metalMachine, infrav1alpha1.IgnitionReadyCondition)).To(BeTrue())
+				Expect(conditions.IsTrue(metalMachine, infrav1alpha1.ServerClaimBoundCondition)).To(BeTrue())
+				Expect(conditions.GetReason(metalMachine, infrav1alpha1.ServerReadyCondition)).To(Equal(infrav1alpha1.WaitingForServerReason))
+
+				By("Verifying the summarized Ready condition reflects the missing Server")
+				Expect(conditions.IsFalse(metalMachine, clusterapiv1beta2.ReadyCondition)).To(BeTrue())
+			})
+
+			It("should report the ServerClaim as not bound yet", func() {
+				server := &metalv1alpha1.Server{
+					ObjectMeta: metav1.ObjectMeta{GenerateName: "available-server-"},
+					Spec:       metalv1alpha1.ServerSpec{SystemUUID: "38947555-7742-3448-3784-823347823838"},
+				}
+				Expect(k8sClient.Create(ctx, server)).To(Succeed())
+				DeferCleanup(k8sClient.Delete, server)
+				Eventually(UpdateStatus(server, func() {
+					server.Status.State = metalv1alpha1.ServerStateAvailable
+				})).Should(Succeed())
+
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), metalMachine)).To(Succeed())
+				condition := conditions.Get(metalMachine, infrav1alpha1.ServerClaimBoundCondition)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(infrav1alpha1.WaitingForServerClaimBindingReason))
+				Expect(conditions.Has(metalMachine, clusterapiv1beta2.ReadyCondition)).To(BeTrue())
+			})
+
+			It("should report insufficient capacity if no Server is available", func() {
+				result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(metalMachine),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(ctrl.Result{}))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), metalMachine)).To(Succeed())
+				Expect(conditions.IsFalse(metalMachine, infrav1alpha1.ServerClaimBoundCondition)).To(BeTrue())
+				Expect(conditions.GetReason(metalMachine, infrav1alpha1.ServerClaimBoundCondition)).To(Equal(infrav1alpha1.InsufficientCapacityReason))
+				Expect(metalMachine.Status.FailureReason).To(BeNil())
+			})
+
+			When("the insufficient capacity timeout has expired", func() {
+				BeforeEach(func() {
+					controllerReconciler.InsufficientCapacityTimeout = time.Nanosecond
+				})
+
+				It("should mark the machine as failed", func() {
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), metalMachine)).To(Succeed())
+					Expect(conditions.GetReason(metalMachine, infrav1alpha1.ServerClaimBoundCondition)).To(Equal(infrav1alpha1.InsufficientCapacityReason))
+					Expect(metalMachine.Status.FailureReason).To(HaveValue(Equal(capierrors.InsufficientResourcesMachineError)))
+					Expect(metalMachine.Status.FailureMessage).To(HaveValue(ContainSubstring("No unclaimed Server matches")))
+
+					By("Setting the ProvisioningFailed condition on the Machine")
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+					Expect(conditions.IsTrue(machine, infrav1alpha1.ProvisioningFailedCondition)).To(BeTrue())
+					Expect(conditions.GetReason(machine, infrav1alpha1.ProvisioningFailedCondition)).To(Equal(infrav1alpha1.InsufficientCapacityReason))
+
+					By("Deleting the pending ServerClaim")
+					_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(Get(&metalv1alpha1.ServerClaim{ObjectMeta: metav1.ObjectMeta{Namespace: metalMachine.Namespace, Name: metalMachine.Name}})).Should(Satisfy(apierrors.IsNotFound))
+				})
+
+				It("should clear the failure once a Server becomes available", func() {
+					_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					Expect(err).NotTo(HaveOccurred())
+					_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					server := &metalv1alpha1.Server{
+						ObjectMeta: metav1.ObjectMeta{GenerateName: "available-server-"},
+						Spec:       metalv1alpha1.ServerSpec{SystemUUID: "38947555-7742-3448-3784-823347823839"},
+					}
+					Expect(k8sClient.Create(ctx, server)).To(Succeed())
+					DeferCleanup(k8sClient.Delete, server)
+					Eventually(UpdateStatus(server, func() {
+						server.Status.State = metalv1alpha1.ServerStateAvailable
+					})).Should(Succeed())
+
+					_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+						NamespacedName: client.ObjectKeyFromObject(metalMachine),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), metalMachine)).To(Succeed())
+					Expect(metalMachine.Status.FailureReason).To(BeNil())
+					Expect(metalMachine.Status.FailureMessage).To(BeNil())
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+					Expect(conditions.IsFalse(machine, infrav1alpha1.ProvisioningFailedCondition)).To(BeTrue())
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), &metalv1alpha1.ServerClaim{})).To(Succeed())
+				})
 			})
 
 			When("the tolerations are present in the metal machine", func() {
@@ -467,7 +1453,7 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 					Namespace: namespace,
 				},
 				Data: map[string][]byte{
-					bootstrapDataKey: []byte(fmt.Sprintf(`{"name": "%s"}`, metalHostnamePlaceholder)),
+					bootstrapDataKey: []byte(`{"name": "%24%24%7BMETAL_HOSTNAME%7D"}`),
 				},
 			}
 			metalCluster = &infrav1alpha1.IroncoreMetalCluster{
@@ -617,7 +1603,215 @@ var _ = Describe("IroncoreMetalMachine Controller", func() {
 				})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(out).To(Equal(ctrl.Result{}))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), metalMachine)).To(Succeed())
+				condition := conditions.Get(metalMachine, infrav1alpha1.BootstrapDataReadyCondition)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(infrav1alpha1.WaitingForBootstrapDataReason))
 			})
 		})
 	})
 })
+
+// envtestKubeconfig returns a kubeconfig of the envtest cluster, which stands in for a remote metal cluster.
+func envtestKubeconfig() []byte {
+	kubeconfig, err := clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{"metal": {
+			Server:                   cfg.Host,
+			CertificateAuthorityData: cfg.CAData,
+		}},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{"metal": {
+			ClientCertificateData: cfg.CertData,
+			ClientKeyData:         cfg.KeyData,
+		}},
+		Contexts:       map[string]*clientcmdapi.Context{"metal": {Cluster: "metal", AuthInfo: "metal"}},
+		CurrentContext: "metal",
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return kubeconfig
+}
+
+var _ = Describe("Metal cluster client", func() {
+	const namespace = "default"
+
+	var (
+		ctx                  = context.Background()
+		metalCluster         *infrav1alpha1.IroncoreMetalCluster
+		controllerReconciler *IroncoreMetalMachineReconciler
+	)
+
+	BeforeEach(func() {
+		metalCluster = &infrav1alpha1.IroncoreMetalCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "metal-cluster",
+				Namespace: namespace,
+			},
+		}
+		controllerReconciler = &IroncoreMetalMachineReconciler{
+			Client:        k8sClient,
+			Scheme:        k8sClient.Scheme(),
+			MetalClusters: &MetalClusters{},
+		}
+	})
+
+	It("should use the management cluster without a kubeconfig reference", func() {
+		metalClient, metalNamespace, err := metalClusterClient(ctx, k8sClient, controllerReconciler.MetalClusters, metalCluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metalClient).To(BeIdenticalTo(k8sClient))
+		Expect(metalNamespace).To(Equal(namespace))
+	})
+
+	It("should create and cache the client of the referenced kubeconfig", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "metal-kubeconfig-",
+				Namespace:    namespace,
+			},
+			Data: map[string][]byte{infrav1alpha1.MetalKubeconfigSecretKey: envtestKubeconfig()},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, secret)
+		metalCluster.Spec.MetalKubeconfigSecretRef = &infrav1alpha1.MetalKubeconfigSecretReference{
+			Name:            secret.Name,
+			TargetNamespace: "metal",
+		}
+
+		metalClient, metalNamespace, err := metalClusterClient(ctx, k8sClient, controllerReconciler.MetalClusters, metalCluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metalClient).NotTo(BeIdenticalTo(k8sClient))
+		Expect(metalNamespace).To(Equal("metal"))
+		Expect(metalClient.List(ctx, &metalv1alpha1.ServerClaimList{}, client.InNamespace(metalNamespace))).To(Succeed())
+
+		cachedClient, _, err := metalClusterClient(ctx, k8sClient, controllerReconciler.MetalClusters, metalCluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cachedClient).To(BeIdenticalTo(metalClient))
+	})
+
+	It("should not block the clients of other metal clusters while a metal cluster is started", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "metal-kubeconfig-",
+				Namespace:    namespace,
+			},
+			Data: map[string][]byte{infrav1alpha1.MetalKubeconfigSecretKey: envtestKubeconfig()},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, secret)
+		metalCluster.Spec.MetalKubeconfigSecretRef = &infrav1alpha1.MetalKubeconfigSecretReference{Name: secret.Name}
+
+		By("Holding the lock of a metal cluster which is being started")
+		otherEntry := controllerReconciler.MetalClusters.entry(metalClusterKey{
+			secret:    client.ObjectKey{Namespace: namespace, Name: "unreachable-kubeconfig"},
+			namespace: namespace,
+		})
+		otherEntry.mu.Lock()
+		defer otherEntry.mu.Unlock()
+
+		_, _, err := metalClusterClient(ctx, k8sClient, controllerReconciler.MetalClusters, metalCluster)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should stop the metal cluster once the last IroncoreMetalCluster referencing it is deleted", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "metal-kubeconfig-",
+				Namespace:    namespace,
+			},
+			Data: map[string][]byte{infrav1alpha1.MetalKubeconfigSecretKey: envtestKubeconfig()},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, secret)
+		metalCluster.Spec.MetalKubeconfigSecretRef = &infrav1alpha1.MetalKubeconfigSecretReference{Name: secret.Name}
+		otherMetalCluster := &infrav1alpha1.IroncoreMetalCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "other-metal-cluster-",
+				Namespace:    namespace,
+			},
+			Spec: infrav1alpha1.IroncoreMetalClusterSpec{MetalKubeconfigSecretRef: metalCluster.Spec.MetalKubeconfigSecretRef},
+		}
+		Expect(k8sClient.Create(ctx, otherMetalCluster)).To(Succeed())
+
+		_, _, err := metalClusterClient(ctx, k8sClient, controllerReconciler.MetalClusters, metalCluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(controllerReconciler.MetalClusters.clusters).To(HaveKey(metalClusterKeyOf(metalCluster)))
+
+		By("Keeping the metal cluster which is still referenced by another IroncoreMetalCluster")
+		Expect(controllerReconciler.MetalClusters.release(ctx, k8sClient, metalCluster)).To(Succeed())
+		Expect(controllerReconciler.MetalClusters.clusters).To(HaveKey(metalClusterKeyOf(metalCluster)))
+
+		By("Stopping the metal cluster once the other IroncoreMetalCluster is deleted")
+		Expect(k8sClient.Delete(ctx, otherMetalCluster)).To(Succeed())
+		Expect(controllerReconciler.MetalClusters.release(ctx, k8sClient, metalCluster)).To(Succeed())
+		Expect(controllerReconciler.MetalClusters.clusters).NotTo(HaveKey(metalClusterKeyOf(metalCluster)))
+	})
+
+	It("should fail if the kubeconfig secret has no kubeconfig", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "metal-kubeconfig-",
+				Namespace:    namespace,
+			},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, secret)
+		metalCluster.Spec.MetalKubeconfigSecretRef = &infrav1alpha1.MetalKubeconfigSecretReference{Name: secret.Name}
+
+		_, _, err := metalClusterClient(ctx, k8sClient, controllerReconciler.MetalClusters, metalCluster)
+		Expect(err).To(MatchError(ContainSubstring("has no \"kubeconfig\" key")))
+	})
+
+	It("should qualify the names of the objects in a remote cluster with the namespace of the machine", func() {
+		metalCluster.Spec.MetalKubeconfigSecretRef = &infrav1alpha1.MetalKubeconfigSecretReference{Name: "metal-kubeconfig", TargetNamespace: "metal"}
+		machineScope := func(namespace string) *scope.MachineScope {
+			return &scope.MachineScope{
+				IroncoreMetalCluster: metalCluster,
+				IroncoreMetalMachine: &infrav1alpha1.IroncoreMetalMachine{ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: namespace}},
+				MetalNamespace:       "metal",
+			}
+		}
+
+		first, second := machineScope("first"), machineScope("second")
+		Expect(serverClaimKey(first).Namespace).To(Equal("metal"))
+		Expect(serverClaimKey(first).Name).To(HavePrefix("first-machine-"))
+		Expect(serverClaimKey(first)).NotTo(Equal(serverClaimKey(second)))
+		Expect(ignitionSecretName(first)).To(Equal("ignition-" + serverClaimKey(first).Name))
+
+		serverClaim := &metalv1alpha1.ServerClaim{ObjectMeta: metav1.ObjectMeta{Labels: metalObjectLabels(first.IroncoreMetalMachine)}}
+		Expect(ownsMetalObject(first, serverClaim)).To(BeTrue())
+		Expect(ownsMetalObject(second, serverClaim)).To(BeFalse())
+	})
+
+	It("should only release a deleting machine of an unavailable metal cluster with the OrphanMetalObjectsAnnotation", func() {
+		metalMachine := &infrav1alpha1.IroncoreMetalMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "metal-machine-",
+				Namespace:    namespace,
+				Finalizers:   []string{IroncoreMetalMachineFinalizer},
+			},
+			Spec: infrav1alpha1.IroncoreMetalMachineSpec{Image: "image"},
+		}
+		Expect(k8sClient.Create(ctx, metalMachine)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, metalMachine)).To(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), metalMachine)).To(Succeed())
+		logger := ctrl.Log
+		machineScope := &scope.MachineScope{
+			Logger:               &logger,
+			IroncoreMetalCluster: metalCluster,
+			IroncoreMetalMachine: metalMachine,
+		}
+		metalClusterErr := fmt.Errorf("failed to get the metal kubeconfig secret metal-kubeconfig")
+
+		_, err := controllerReconciler.reconcileMetalClusterUnavailable(ctx, machineScope, metalClusterErr)
+		Expect(err).To(MatchError(metalClusterErr))
+		Expect(conditions.GetReason(metalMachine, infrav1alpha1.ServerClaimBoundCondition)).To(Equal(infrav1alpha1.MetalClusterUnavailableReason))
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), metalMachine)).To(Succeed())
+		Expect(metalMachine.Finalizers).To(ContainElement(IroncoreMetalMachineFinalizer))
+
+		By("Opting into orphaning the objects in the metal cluster")
+		metalCluster.Annotations = map[string]string{infrav1alpha1.OrphanMetalObjectsAnnotation: "true"}
+		_, err = controllerReconciler.reconcileMetalClusterUnavailable(ctx, machineScope, metalClusterErr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(metalMachine), metalMachine)).To(Satisfy(apierrors.IsNotFound))
+	})
+})
@@ -0,0 +1,316 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// LabelKeyServerGPUCount is the number of GPUs of a Server. The inventory of the metal-operator does not report
+	// GPUs, so they are taken from this label for the capacity of an IroncoreMetalMachineTemplate.
+	LabelKeyServerGPUCount = "metal.ironcore.dev/gpu-count"
+
+	// resourceNvidiaGPU is the resource name of the GPUs in the capacity of an IroncoreMetalMachineTemplate.
+	resourceNvidiaGPU corev1.ResourceName = "nvidia.com/gpu"
+
+	// operatingSystemLinux is the operating system of all machines, the boot images are configured via ignition.
+	operatingSystemLinux = "linux"
+)
+
+// IroncoreMetalMachineTemplateReconciler reconciles the status of an IroncoreMetalMachineTemplate from the Servers
+// matching its ServerSelector and Tolerations, so the cluster autoscaler can scale MachineDeployments from zero.
+type IroncoreMetalMachineTemplateReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// MetalClusters caches the remote clusters referenced by the MetalKubeconfigSecretRef of the IroncoreMetalClusters.
+	// Their Servers are watched.
+	MetalClusters *MetalClusters
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalmachinetemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalmachinetemplates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=metal.ironcore.dev,resources=servers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *IroncoreMetalMachineTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	template := &infrav1alpha1.IroncoreMetalMachineTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !template.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if annotations.HasPaused(template) {
+		logger.Info("IroncoreMetalMachineTemplate is marked as paused, not reconciling")
+		return ctrl.Result{}, nil
+	}
+
+	metalClient, err := r.templateMetalClient(ctx, template)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	servers, err := matchingServers(ctx, metalClient, template.Spec.Template.Spec.ServerSelector, template.Spec.Template.Spec.Tolerations)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	templateBase := template.DeepCopy()
+	template.Status = templateStatus(servers)
+	if equality.Semantic.DeepEqual(templateBase.Status, template.Status) {
+		return ctrl.Result{}, nil
+	}
+	if err := r.Status().Patch(ctx, template, client.MergeFrom(templateBase)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch the status of IroncoreMetalMachineTemplate: %w", err)
+	}
+	logger.V(4).Info("Updated the capacity", "Capacity", template.Status.Capacity, "AvailableServers", template.Status.AvailableServers)
+	return ctrl.Result{}, nil
+}
+
+// templateMetalClient returns the client of the cluster running the metal-operator the machines of the
+// IroncoreMetalMachineTemplate are provisioned from. It is the one of the IroncoreMetalCluster of the Cluster owning or
+// labelling the template. A template which belongs to no Cluster is matched with the Servers of the management cluster.
+func (r *IroncoreMetalMachineTemplateReconciler) templateMetalClient(ctx context.Context, template *infrav1alpha1.IroncoreMetalMachineTemplate) (client.Client, error) {
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, template.ObjectMeta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the owner Cluster: %w", err)
+	}
+	if cluster == nil {
+		clusterName, ok := template.Labels[clusterv1.ClusterNameLabel]
+		if !ok {
+			return r.Client, nil
+		}
+		if cluster, err = util.GetClusterByName(ctx, r.Client, template.Namespace, clusterName); err != nil {
+			return nil, fmt.Errorf("failed to get the Cluster %s: %w", clusterName, err)
+		}
+	}
+	if cluster.Spec.InfrastructureRef.Name == "" {
+		return r.Client, nil
+	}
+
+	metalCluster := &infrav1alpha1.IroncoreMetalCluster{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.InfrastructureRef.Name}, metalCluster); err != nil {
+		return nil, fmt.Errorf("failed to get the IroncoreMetalCluster %s: %w", cluster.Spec.InfrastructureRef.Name, err)
+	}
+	metalClient, _, err := metalClusterClient(ctx, r.Client, r.MetalClusters, metalCluster)
+	return metalClient, err
+}
+
+// templateStatus returns the status of an IroncoreMetalMachineTemplate matching the Servers. The capacity is the
+// minimum of the Servers with an inventory, the architecture is only reported if all of them share it.
+func templateStatus(servers []metalv1alpha1.Server) infrav1alpha1.IroncoreMetalMachineTemplateStatus {
+	status := infrav1alpha1.IroncoreMetalMachineTemplateStatus{
+		NodeInfo: infrav1alpha1.NodeInfo{OperatingSystem: operatingSystemLinux},
+	}
+
+	architectures := make(map[infrav1alpha1.Architecture]bool)
+	for _, server := range servers {
+		if server.Spec.ServerClaimRef == nil && server.Status.State == metalv1alpha1.ServerStateAvailable {
+			status.AvailableServers++
+		}
+		capacity := serverCapacity(&server)
+		if capacity == nil {
+			continue
+		}
+		if status.Capacity == nil {
+			status.Capacity = capacity
+		} else {
+			for name, quantity := range status.Capacity {
+				if other, ok := capacity[name]; !ok {
+					delete(status.Capacity, name)
+				} else if other.Cmp(quantity) < 0 {
+					status.Capacity[name] = other
+				}
+			}
+		}
+		architectures[serverArchitecture(&server)] = true
+	}
+
+	if len(architectures) == 1 {
+		for architecture := range architectures {
+			status.NodeInfo.Architecture = architecture
+		}
+	}
+	return status
+}
+
+// serverCapacity returns the CPUs, the memory and the GPUs of a Server. It returns nil if the Server has not been
+// discovered yet.
+func serverCapacity(server *metalv1alpha1.Server) corev1.ResourceList {
+	var cpus int64
+	for _, processor := range server.Status.Processors {
+		if processor.TotalThreads > 0 {
+			cpus += int64(processor.TotalThreads)
+		} else {
+			cpus += int64(processor.TotalCores)
+		}
+	}
+	if cpus == 0 || server.Status.TotalSystemMemory == nil {
+		return nil
+	}
+
+	capacity := corev1.ResourceList{
+		corev1.ResourceCPU:    *resource.NewQuantity(cpus, resource.DecimalSI),
+		corev1.ResourceMemory: server.Status.TotalSystemMemory.DeepCopy(),
+	}
+	if gpus, err := strconv.ParseInt(server.Labels[LabelKeyServerGPUCount], 10, 64); err == nil && gpus > 0 {
+		capacity[resourceNvidiaGPU] = *resource.NewQuantity(gpus, resource.DecimalSI)
+	}
+	return capacity
+}
+
+// serverArchitecture maps the instruction set of the first processor of a Server reported by Redfish to the node
+// architecture. It is empty if the instruction set is unknown.
+func serverArchitecture(server *metalv1alpha1.Server) infrav1alpha1.Architecture {
+	if len(server.Status.Processors) == 0 {
+		return ""
+	}
+	switch server.Status.Processors[0].InstructionSet {
+	case "x86-64":
+		return infrav1alpha1.ArchitectureAmd64
+	case "ARM-A64":
+		return infrav1alpha1.ArchitectureArm64
+	case "PowerISA":
+		return infrav1alpha1.ArchitecturePpc64le
+	}
+	return ""
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IroncoreMetalMachineTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1alpha1.IroncoreMetalMachineTemplate{}).
+		Watches(
+			&metalv1alpha1.Server{},
+			r.serverEventHandler(),
+			builder.WithPredicates(serverCapacityChangedPredicate()),
+		).
+		Build(r)
+	if err != nil {
+		return err
+	}
+	if r.MetalClusters == nil {
+		return nil
+	}
+	return r.MetalClusters.addWatches(func(metalCluster cluster.Cluster) error {
+		return c.Watch(source.Kind[client.Object](metalCluster.GetCache(), &metalv1alpha1.Server{},
+			r.serverEventHandler(), serverCapacityChangedPredicate()))
+	})
+}
+
+// serverCapacityChangedPredicate filters the updates of a Server which do not change whether it matches an
+// IroncoreMetalMachineTemplate, whether it is available or the capacity it provides.
+func serverCapacityChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldServer, ok := e.ObjectOld.(*metalv1alpha1.Server)
+			if !ok {
+				return false
+			}
+			newServer, ok := e.ObjectNew.(*metalv1alpha1.Server)
+			if !ok {
+				return false
+			}
+			return serverCapacityChanged(oldServer, newServer)
+		},
+	}
+}
+
+// serverCapacityChanged reports whether the labels, the taints, the availability or the inventory of a Server differ.
+func serverCapacityChanged(oldServer, newServer *metalv1alpha1.Server) bool {
+	return !maps.Equal(oldServer.Labels, newServer.Labels) ||
+		!equality.Semantic.DeepEqual(oldServer.Spec.Taints, newServer.Spec.Taints) ||
+		(oldServer.Spec.ServerClaimRef == nil) != (newServer.Spec.ServerClaimRef == nil) ||
+		oldServer.Status.State != newServer.Status.State ||
+		!equality.Semantic.DeepEqual(oldServer.Status.Processors, newServer.Status.Processors) ||
+		!equality.Semantic.DeepEqual(oldServer.Status.TotalSystemMemory, newServer.Status.TotalSystemMemory)
+}
+
+// serverEventHandler enqueues the IroncoreMetalMachineTemplates matching a Server. On an update the templates matching
+// the Server before the update are enqueued as well, so a Server is removed from the status of the templates it no
+// longer matches.
+func (r *IroncoreMetalMachineTemplateReconciler) serverEventHandler() handler.EventHandler {
+	enqueue := func(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request], objs ...client.Object) {
+		for _, obj := range objs {
+			for _, request := range r.serverToIroncoreMetalMachineTemplates(ctx, obj) {
+				queue.Add(request)
+			}
+		}
+	}
+	return handler.Funcs{
+		CreateFunc: func(ctx context.Context, e event.CreateEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, queue, e.Object)
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, queue, e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc: func(ctx context.Context, e event.DeleteEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, queue, e.Object)
+		},
+		GenericFunc: func(ctx context.Context, e event.GenericEvent, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueue(ctx, queue, e.Object)
+		},
+	}
+}
+
+// serverToIroncoreMetalMachineTemplates maps a Server to the IroncoreMetalMachineTemplates whose ServerSelector
+// matches its labels. The Tolerations are not considered, as a change of the taints of a Server changes whether it
+// is tolerated.
+func (r *IroncoreMetalMachineTemplateReconciler) serverToIroncoreMetalMachineTemplates(ctx context.Context, obj client.Object) []reconcile.Request {
+	templates := &infrav1alpha1.IroncoreMetalMachineTemplateList{}
+	if err := r.List(ctx, templates); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list IroncoreMetalMachineTemplates")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, template := range templates.Items {
+		selector := labels.Everything()
+		if serverSelector := template.Spec.Template.Spec.ServerSelector; serverSelector != nil {
+			var err error
+			if selector, err = metav1.LabelSelectorAsSelector(serverSelector); err != nil {
+				continue
+			}
+		}
+		if selector.Matches(labels.Set(obj.GetLabels())) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&template)})
+		}
+	}
+	return requests
+}
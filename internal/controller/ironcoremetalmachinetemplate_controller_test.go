@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterapiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+var _ = Describe("IroncoreMetalMachineTemplate Controller", func() {
+	const namespace = "default"
+
+	var (
+		ctx                  = context.Background()
+		servers              []*metalv1alpha1.Server
+		template             *infrav1alpha1.IroncoreMetalMachineTemplate
+		controllerReconciler *IroncoreMetalMachineTemplateReconciler
+
+		newServer = func(labels map[string]string, taints []metalv1alpha1.Taint, threads int32, memory string) *metalv1alpha1.Server {
+			return &metalv1alpha1.Server{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "template-server-",
+					Labels:       labels,
+				},
+				Spec: metalv1alpha1.ServerSpec{
+					SystemUUID: "38947555-7742-3448-3784-823347823837",
+					Taints:     taints,
+				},
+				Status: metalv1alpha1.ServerStatus{
+					State:             metalv1alpha1.ServerStateAvailable,
+					TotalSystemMemory: ptrQuantity(memory),
+					Processors: []metalv1alpha1.Processor{{
+						ID:             "CPU0",
+						InstructionSet: "x86-64",
+						TotalCores:     threads / 2,
+						TotalThreads:   threads,
+					}},
+				},
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		noBind := []metalv1alpha1.Taint{{Key: "dedicated", Value: "gpu", Effect: metalv1alpha1.TaintEffectNoBind}}
+		servers = []*metalv1alpha1.Server{
+			newServer(map[string]string{"type": "compute", LabelKeyServerGPUCount: "2"}, nil, 64, "256Gi"),
+			newServer(map[string]string{"type": "compute", LabelKeyServerGPUCount: "1"}, noBind, 32, "128Gi"),
+			newServer(map[string]string{"type": "storage"}, nil, 8, "32Gi"),
+		}
+
+		template = &infrav1alpha1.IroncoreMetalMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "template-",
+				Namespace:    namespace,
+			},
+			Spec: infrav1alpha1.IroncoreMetalMachineTemplateSpec{
+				Template: infrav1alpha1.IroncoreMetalMachineTemplateResource{
+					Spec: infrav1alpha1.IroncoreMetalMachineSpec{
+						Image:          "ghcr.io/ironcore-dev/os-images/gardenlinux:1443.3",
+						ServerSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"type": "compute"}},
+					},
+				},
+			},
+		}
+
+		controllerReconciler = &IroncoreMetalMachineTemplateReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	JustBeforeEach(func() {
+		for _, server := range servers {
+			status := server.Status
+			Expect(k8sClient.Create(ctx, server)).To(Succeed())
+			Eventually(UpdateStatus(server, func() {
+				server.Status = status
+			})).Should(Succeed())
+		}
+		Expect(k8sClient.Create(ctx, template)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		for _, server := range servers {
+			Expect(k8sClient.Delete(ctx, server)).To(Succeed())
+		}
+		Expect(k8sClient.Delete(ctx, template)).To(Succeed())
+	})
+
+	It("should publish the capacity of the matching Servers", func() {
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(template)})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(Object(template)).Should(HaveField("Status", SatisfyAll(
+			HaveField("Capacity", HaveLen(3)),
+			HaveField("Capacity", HaveKeyWithValue(corev1.ResourceCPU, BeComparableTo(resource.MustParse("64")))),
+			HaveField("Capacity", HaveKeyWithValue(corev1.ResourceMemory, BeComparableTo(resource.MustParse("256Gi")))),
+			HaveField("Capacity", HaveKeyWithValue(resourceNvidiaGPU, BeComparableTo(resource.MustParse("2")))),
+			HaveField("NodeInfo", infrav1alpha1.NodeInfo{
+				Architecture:    infrav1alpha1.ArchitectureAmd64,
+				OperatingSystem: operatingSystemLinux,
+			}),
+			HaveField("AvailableServers", int32(1)),
+		)))
+	})
+
+	When("the template tolerates the taint of a Server", func() {
+		BeforeEach(func() {
+			template.Spec.Template.Spec.Tolerations = []metalv1alpha1.Toleration{{
+				Key:      "dedicated",
+				Operator: metalv1alpha1.TolerationOperatorExists,
+			}}
+		})
+
+		It("should publish the minimum capacity of the matching Servers", func() {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(template)})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(Object(template)).Should(HaveField("Status", SatisfyAll(
+				HaveField("Capacity", HaveKeyWithValue(corev1.ResourceCPU, BeComparableTo(resource.MustParse("32")))),
+				HaveField("Capacity", HaveKeyWithValue(corev1.ResourceMemory, BeComparableTo(resource.MustParse("128Gi")))),
+				HaveField("Capacity", HaveKeyWithValue(resourceNvidiaGPU, BeComparableTo(resource.MustParse("1")))),
+				HaveField("AvailableServers", int32(2)),
+			)))
+		})
+	})
+
+	When("the template belongs to a Cluster with a remote metal cluster", func() {
+		BeforeEach(func() {
+			kubeconfigSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "metal-kubeconfig-",
+					Namespace:    namespace,
+				},
+				Data: map[string][]byte{infrav1alpha1.MetalKubeconfigSecretKey: envtestKubeconfig()},
+			}
+			Expect(k8sClient.Create(ctx, kubeconfigSecret)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, kubeconfigSecret)
+
+			metalCluster := &infrav1alpha1.IroncoreMetalCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "template-metal-cluster-",
+					Namespace:    namespace,
+				},
+				Spec: infrav1alpha1.IroncoreMetalClusterSpec{
+					MetalKubeconfigSecretRef: &infrav1alpha1.MetalKubeconfigSecretReference{Name: kubeconfigSecret.Name},
+				},
+			}
+			Expect(k8sClient.Create(ctx, metalCluster)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, metalCluster)
+
+			cluster := &clusterapiv1beta2.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "template-cluster-",
+					Namespace:    namespace,
+				},
+				Spec: clusterapiv1beta2.ClusterSpec{
+					InfrastructureRef: clusterapiv1beta2.ContractVersionedObjectReference{
+						APIGroup: infrav1alpha1.GroupVersion.Group,
+						Kind:     "IroncoreMetalCluster",
+						Name:     metalCluster.Name,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+			DeferCleanup(k8sClient.Delete, cluster)
+
+			template.Labels = map[string]string{clusterapiv1beta2.ClusterNameLabel: cluster.Name}
+			controllerReconciler.MetalClusters = &MetalClusters{}
+		})
+
+		It("should publish the capacity of the Servers of the remote metal cluster", func() {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(template)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(controllerReconciler.MetalClusters.clusters).To(HaveLen(1))
+
+			Eventually(Object(template)).Should(HaveField("Status", SatisfyAll(
+				HaveField("Capacity", HaveKeyWithValue(corev1.ResourceCPU, BeComparableTo(resource.MustParse("64")))),
+				HaveField("AvailableServers", int32(1)),
+			)))
+		})
+	})
+
+	It("should map a Server to the templates matching it", func() {
+		Expect(controllerReconciler.serverToIroncoreMetalMachineTemplates(ctx, servers[0])).To(
+			ContainElement(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(template)}))
+		Expect(controllerReconciler.serverToIroncoreMetalMachineTemplates(ctx, servers[2])).NotTo(
+			ContainElement(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(template)}))
+	})
+})
+
+var _ = Describe("Template status", func() {
+	It("should omit the capacity and the architecture of Servers without an inventory", func() {
+		status := templateStatus([]metalv1alpha1.Server{{
+			Status: metalv1alpha1.ServerStatus{State: metalv1alpha1.ServerStateAvailable},
+		}})
+		Expect(status).To(Equal(infrav1alpha1.IroncoreMetalMachineTemplateStatus{
+			NodeInfo:         infrav1alpha1.NodeInfo{OperatingSystem: operatingSystemLinux},
+			AvailableServers: 1,
+		}))
+	})
+
+	It("should omit the architecture and the GPUs if the Servers differ", func() {
+		status := templateStatus([]metalv1alpha1.Server{
+			{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{LabelKeyServerGPUCount: "4"}},
+				Status: metalv1alpha1.ServerStatus{
+					TotalSystemMemory: ptrQuantity("64Gi"),
+					Processors:        []metalv1alpha1.Processor{{InstructionSet: "x86-64", TotalCores: 16}},
+				},
+			},
+			{
+				Status: metalv1alpha1.ServerStatus{
+					TotalSystemMemory: ptrQuantity("128Gi"),
+					Processors:        []metalv1alpha1.Processor{{InstructionSet: "ARM-A64", TotalCores: 8}},
+				},
+			},
+		})
+		Expect(status.Capacity).To(BeComparableTo(corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("8"),
+			corev1.ResourceMemory: resource.MustParse("64Gi"),
+		}))
+		Expect(status.NodeInfo.Architecture).To(BeEmpty())
+		Expect(status.AvailableServers).To(BeZero())
+	})
+})
+
+func ptrQuantity(value string) *resource.Quantity {
+	quantity := resource.MustParse(value)
+	return &quantity
+}
+
+var _ = Describe("Server capacity changes", func() {
+	var server *metalv1alpha1.Server
+
+	BeforeEach(func() {
+		server = &metalv1alpha1.Server{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"type": "compute"}},
+			Status: metalv1alpha1.ServerStatus{
+				State:             metalv1alpha1.ServerStateAvailable,
+				TotalSystemMemory: ptrQuantity("64Gi"),
+			},
+		}
+	})
+
+	It("should ignore updates which do not change the capacity", func() {
+		updated := server.DeepCopy()
+		updated.Status.PowerState = metalv1alpha1.ServerOnPowerState
+		updated.Annotations = map[string]string{"foo": "bar"}
+		Expect(serverCapacityChanged(server, updated)).To(BeFalse())
+	})
+
+	It("should detect changes of the labels, the taints, the state and the inventory", func() {
+		for _, mutate := range []func(*metalv1alpha1.Server){
+			func(s *metalv1alpha1.Server) { s.Labels["type"] = "storage" },
+			func(s *metalv1alpha1.Server) {
+				s.Spec.Taints = []metalv1alpha1.Taint{{Key: "dedicated", Effect: metalv1alpha1.TaintEffectNoBind}}
+			},
+			func(s *metalv1alpha1.Server) {
+				s.Spec.ServerClaimRef = &metalv1alpha1.ImmutableObjectReference{Name: "claim"}
+			},
+			func(s *metalv1alpha1.Server) { s.Status.State = metalv1alpha1.ServerStateReserved },
+			func(s *metalv1alpha1.Server) { s.Status.TotalSystemMemory = ptrQuantity("128Gi") },
+		} {
+			updated := server.DeepCopy()
+			mutate(updated)
+			Expect(serverCapacityChanged(server, updated)).To(BeTrue())
+		}
+	})
+})
@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	clusterapiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	defaultRemediationRetryLimit = 1
+	defaultRemediationTimeout    = 5 * time.Minute
+)
+
+// IroncoreMetalRemediationReconciler reconciles an IroncoreMetalRemediation object. It implements the external
+// remediation contract of the MachineHealthCheck: the Server of the unhealthy Machine is power cycled up to the retry
+// limit and the Machine is deleted if its node does not recover.
+type IroncoreMetalRemediationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalremediations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalremediations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalremediationtemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalmachines,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;delete
+
+func (r *IroncoreMetalRemediationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	logger := log.FromContext(ctx)
+
+	remediation := &infrav1alpha1.IroncoreMetalRemediation{}
+	if err := r.Get(ctx, req.NamespacedName, remediation); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !remediation.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// The MachineHealthCheck creates the remediation with an owner reference to the unhealthy Machine.
+	machine, err := util.GetOwnerMachine(ctx, r.Client, remediation.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if machine == nil {
+		logger.Info("MachineHealthCheck has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+
+	logger = logger.WithValues("machine", klog.KObj(machine))
+
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machine.ObjectMeta)
+	if err != nil {
+		logger.Info("Machine is missing cluster label or cluster does not exist")
+		return ctrl.Result{}, nil
+	}
+
+	if annotations.IsPaused(cluster, remediation) {
+		logger.Info("IroncoreMetalRemediation or linked Cluster is marked as paused, not reconciling")
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(remediation, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper: %w", err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, remediation); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	return r.reconcileNormal(ctx, &logger, remediation, machine)
+}
+
+func (r *IroncoreMetalRemediationReconciler) reconcileNormal(ctx context.Context, log *logr.Logger, remediation *infrav1alpha1.IroncoreMetalRemediation, machine *clusterapiv1beta2.Machine) (ctrl.Result, error) {
+	retryLimit, timeout := remediationStrategy(remediation)
+
+	switch remediation.Status.Phase {
+	case infrav1alpha1.RemediationPhaseSucceeded:
+		return ctrl.Result{}, nil
+	case infrav1alpha1.RemediationPhaseDeleting:
+		return ctrl.Result{}, r.deleteMachine(ctx, log, machine)
+	case infrav1alpha1.RemediationPhaseRunning:
+		if conditions.IsTrue(machine, clusterapiv1beta2.MachineHealthCheckSucceededCondition) {
+			log.Info("Machine recovered after power cycle", "RetryCount", remediation.Status.RetryCount)
+			remediation.Status.Phase = infrav1alpha1.RemediationPhaseSucceeded
+			return ctrl.Result{}, nil
+		}
+		if remaining := time.Until(remediation.Status.LastRemediated.Add(timeout)); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	if remediation.Status.RetryCount >= retryLimit {
+		log.Info("Machine did not recover within the retry limit, deleting it", "RetryLimit", retryLimit)
+		remediation.Status.Phase = infrav1alpha1.RemediationPhaseDeleting
+		return ctrl.Result{}, r.deleteMachine(ctx, log, machine)
+	}
+
+	if err := r.requestPowerCycle(ctx, log, machine); err != nil {
+		return ctrl.Result{}, err
+	}
+	remediation.Status.Phase = infrav1alpha1.RemediationPhaseRunning
+	remediation.Status.RetryCount++
+	remediation.Status.LastRemediated = &metav1.Time{Time: time.Now()}
+	return ctrl.Result{RequeueAfter: timeout}, nil
+}
+
+// remediationStrategy returns the retry limit and the timeout of the remediation strategy with defaults applied.
+func remediationStrategy(remediation *infrav1alpha1.IroncoreMetalRemediation) (int32, time.Duration) {
+	retryLimit, timeout := int32(defaultRemediationRetryLimit), defaultRemediationTimeout
+	if strategy := remediation.Spec.Strategy; strategy != nil {
+		if strategy.RetryLimit > 0 {
+			retryLimit = strategy.RetryLimit
+		}
+		if strategy.Timeout != nil {
+			timeout = strategy.Timeout.Duration
+		}
+	}
+	return retryLimit, timeout
+}
+
+// requestPowerCycle requests a power cycle of the Server bound to the IroncoreMetalMachine of the Machine via the
+// ServerOperationAnnotation.
+func (r *IroncoreMetalRemediationReconciler) requestPowerCycle(ctx context.Context, log *logr.Logger, machine *clusterapiv1beta2.Machine) error {
+	ironcoremetalmachine := &infrav1alpha1.IroncoreMetalMachine{}
+	key := client.ObjectKey{Namespace: machine.Namespace, Name: machine.Spec.InfrastructureRef.Name}
+	if err := r.Get(ctx, key, ironcoremetalmachine); err != nil {
+		return fmt.Errorf("failed to get IroncoreMetalMachine %s: %w", key, err)
+	}
+
+	ironcoremetalmachineBase := ironcoremetalmachine.DeepCopy()
+	metav1.SetMetaDataAnnotation(&ironcoremetalmachine.ObjectMeta, infrav1alpha1.ServerOperationAnnotation, metalv1alpha1.PowerCycleServerPower)
+	if err := r.Patch(ctx, ironcoremetalmachine, client.MergeFrom(ironcoremetalmachineBase)); err != nil {
+		return fmt.Errorf("failed to request a power cycle of IroncoreMetalMachine %s: %w", key, err)
+	}
+	log.Info("Requested a power cycle of the Server", "IroncoreMetalMachine", ironcoremetalmachine.Name)
+	return nil
+}
+
+func (r *IroncoreMetalRemediationReconciler) deleteMachine(ctx context.Context, log *logr.Logger, machine *clusterapiv1beta2.Machine) error {
+	if !machine.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if err := r.Delete(ctx, machine); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete Machine %s: %w", machine.Name, err)
+	}
+	log.Info("Deleted the unhealthy Machine")
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IroncoreMetalRemediationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1alpha1.IroncoreMetalRemediation{}).
+		Watches(
+			&clusterapiv1beta2.Machine{},
+			handler.EnqueueRequestsFromMapFunc(r.machineToIroncoreMetalRemediation),
+		).
+		Complete(r)
+}
+
+// machineToIroncoreMetalRemediation maps a Machine to the IroncoreMetalRemediation of the same name, which the
+// MachineHealthCheck creates for an unhealthy Machine.
+func (r *IroncoreMetalRemediationReconciler) machineToIroncoreMetalRemediation(_ context.Context, obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(obj)}}
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterapiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+var _ = Describe("IroncoreMetalRemediation Controller", func() {
+	const namespace = "default"
+
+	var (
+		ctx                  = context.Background()
+		cluster              *clusterapiv1beta2.Cluster
+		machine              *clusterapiv1beta2.Machine
+		metalMachine         *infrav1alpha1.IroncoreMetalMachine
+		remediation          *infrav1alpha1.IroncoreMetalRemediation
+		controllerReconciler *IroncoreMetalRemediationReconciler
+
+		get = func(obj client.Object) error {
+			return k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		}
+
+		reconcileRemediation = func() reconcile.Result {
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(remediation),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(get(remediation)).To(Succeed())
+			return result
+		}
+	)
+
+	BeforeEach(func() {
+		cluster = &clusterapiv1beta2.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "remediation-cluster",
+				Namespace: namespace,
+			},
+			Spec: clusterapiv1beta2.ClusterSpec{
+				InfrastructureRef: clusterapiv1beta2.ContractVersionedObjectReference{
+					APIGroup: infrav1alpha1.GroupVersion.Group,
+					Kind:     "IroncoreMetalCluster",
+					Name:     "remediation-metal-cluster",
+				},
+			},
+		}
+
+		machine = &clusterapiv1beta2.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "remediation-machine",
+				Namespace: namespace,
+				Labels:    map[string]string{clusterapiv1beta2.ClusterNameLabel: cluster.Name},
+			},
+			Spec: clusterapiv1beta2.MachineSpec{
+				ClusterName: cluster.Name,
+				Bootstrap: clusterapiv1beta2.Bootstrap{
+					DataSecretName: ptr.To("secret"),
+				},
+				InfrastructureRef: clusterapiv1beta2.ContractVersionedObjectReference{
+					Kind:     ironcoreMetalMachine,
+					Name:     "remediation-metal-machine",
+					APIGroup: infrav1alpha1.GroupVersion.Group,
+				},
+			},
+		}
+
+		metalMachine = &infrav1alpha1.IroncoreMetalMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      machine.Spec.InfrastructureRef.Name,
+				Namespace: namespace,
+			},
+		}
+
+		remediation = &infrav1alpha1.IroncoreMetalRemediation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      machine.Name,
+				Namespace: namespace,
+			},
+			Spec: infrav1alpha1.IroncoreMetalRemediationSpec{
+				Strategy: &infrav1alpha1.RemediationStrategy{
+					Type:       infrav1alpha1.RemediationStrategyPowerCycle,
+					RetryLimit: 1,
+					Timeout:    &metav1.Duration{Duration: time.Hour},
+				},
+			},
+		}
+
+		controllerReconciler = &IroncoreMetalRemediationReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+		Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+		Expect(k8sClient.Create(ctx, metalMachine)).To(Succeed())
+		Expect(controllerutil.SetOwnerReference(machine, remediation, k8sClient.Scheme())).To(Succeed())
+		Expect(k8sClient.Create(ctx, remediation)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, remediation))).To(Succeed())
+		Expect(k8sClient.Delete(ctx, metalMachine)).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, machine))).To(Succeed())
+		Expect(k8sClient.Delete(ctx, cluster)).To(Succeed())
+	})
+
+	It("should request a power cycle of the Server", func() {
+		result := reconcileRemediation()
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+		Expect(remediation.Status.Phase).To(Equal(infrav1alpha1.RemediationPhaseRunning))
+		Expect(remediation.Status.RetryCount).To(Equal(int32(1)))
+		Expect(remediation.Status.LastRemediated).NotTo(BeNil())
+
+		Expect(get(metalMachine)).To(Succeed())
+		Expect(metalMachine.Annotations).To(HaveKeyWithValue(infrav1alpha1.ServerOperationAnnotation, metalv1alpha1.PowerCycleServerPower))
+
+		By("waiting for the node to recover")
+		result = reconcileRemediation()
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(remediation.Status.RetryCount).To(Equal(int32(1)))
+	})
+
+	It("should finish the remediation once the Machine is healthy again", func() {
+		reconcileRemediation()
+
+		Expect(get(machine)).To(Succeed())
+		conditions.Set(machine, metav1.Condition{
+			Type:   clusterapiv1beta2.MachineHealthCheckSucceededCondition,
+			Status: metav1.ConditionTrue,
+			Reason: clusterapiv1beta2.MachineHealthCheckSucceededReason,
+		})
+		Expect(k8sClient.Status().Update(ctx, machine)).To(Succeed())
+
+		reconcileRemediation()
+		Expect(remediation.Status.Phase).To(Equal(infrav1alpha1.RemediationPhaseSucceeded))
+		Expect(get(machine)).To(Succeed())
+	})
+
+	When("the node does not recover within the timeout", func() {
+		BeforeEach(func() {
+			remediation.Spec.Strategy.RetryLimit = 2
+			remediation.Spec.Strategy.Timeout = &metav1.Duration{}
+		})
+
+		It("should retry the power cycle and delete the Machine after the retry limit", func() {
+			reconcileRemediation()
+			Expect(remediation.Status.RetryCount).To(Equal(int32(1)))
+
+			reconcileRemediation()
+			Expect(remediation.Status.Phase).To(Equal(infrav1alpha1.RemediationPhaseRunning))
+			Expect(remediation.Status.RetryCount).To(Equal(int32(2)))
+
+			reconcileRemediation()
+			Expect(remediation.Status.Phase).To(Equal(infrav1alpha1.RemediationPhaseDeleting))
+			Expect(apierrors.IsNotFound(get(machine))).To(BeTrue())
+		})
+	})
+})
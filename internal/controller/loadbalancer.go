@@ -0,0 +1,315 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"regexp"
+	"text/template"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+)
+
+const (
+	defaultKubeVIPImage              = "ghcr.io/kube-vip/kube-vip:v0.8.9"
+	defaultKeepalivedImage           = "osixia/keepalived:2.0.20"
+	defaultHAProxyImage              = "haproxy:2.8"
+	defaultLoadBalancerAPIServerPort = 6443
+	defaultVirtualRouterID           = 51
+	staticPodManifestsDir            = "/etc/kubernetes/manifests"
+	adminKubeconfigPath              = "/etc/kubernetes/admin.conf"
+	superAdminKubeconfigPath         = "/etc/kubernetes/super-admin.conf"
+	scriptFileMode                   = 0755
+)
+
+// staticFile is a file which is added to the ignition of a machine.
+type staticFile struct {
+	Path string
+	Mode int
+	Data []byte
+}
+
+// loadBalancerTemplateData is passed to the templates of the control plane load balancer files.
+type loadBalancerTemplateData struct {
+	Address         string
+	AddressCIDR     int
+	Port            int32
+	APIServerPort   int32
+	Interface       string
+	Image           string
+	HAProxyImage    string
+	VirtualRouterID int32
+	Kubeconfig      string
+}
+
+// kubeadmInitPattern matches the kubeadm init command in plain or URL encoded bootstrap data.
+var kubeadmInitPattern = regexp.MustCompile(`kubeadm(\s|%20)+init\b`)
+
+// isKubeadmInit reports whether the bootstrap data initializes the control plane with kubeadm init.
+func isKubeadmInit(bootstrapData []byte) bool {
+	return kubeadmInitPattern.Match(bootstrapData)
+}
+
+var (
+	kubeVIPManifestTemplate = template.Must(template.New("kube-vip").Parse(`apiVersion: v1
+kind: Pod
+metadata:
+  name: kube-vip
+  namespace: kube-system
+spec:
+  containers:
+  - name: kube-vip
+    image: {{ .Image }}
+    imagePullPolicy: IfNotPresent
+    args:
+    - manager
+    env:
+    - name: vip_arp
+      value: "true"
+    - name: port
+      value: "{{ .Port }}"
+    - name: vip_interface
+      value: {{ .Interface }}
+    - name: vip_cidr
+      value: "{{ .AddressCIDR }}"
+    - name: cp_enable
+      value: "true"
+    - name: cp_namespace
+      value: kube-system
+    - name: vip_leaderelection
+      value: "true"
+    - name: vip_leasename
+      value: plndr-cp-lock
+    - name: vip_leaseduration
+      value: "15"
+    - name: vip_renewdeadline
+      value: "10"
+    - name: vip_retryperiod
+      value: "2"
+    - name: address
+      value: {{ .Address }}
+    securityContext:
+      capabilities:
+        add:
+        - NET_ADMIN
+        - NET_RAW
+    volumeMounts:
+    - mountPath: /etc/kubernetes/admin.conf
+      name: kubeconfig
+  hostAliases:
+  - hostnames:
+    - kubernetes
+    ip: 127.0.0.1
+  hostNetwork: true
+  volumes:
+  - hostPath:
+      path: {{ .Kubeconfig }}
+    name: kubeconfig
+`))
+
+	keepalivedManifestTemplate = template.Must(template.New("keepalived").Parse(`apiVersion: v1
+kind: Pod
+metadata:
+  name: keepalived
+  namespace: kube-system
+spec:
+  containers:
+  - name: keepalived
+    image: {{ .Image }}
+    imagePullPolicy: IfNotPresent
+    securityContext:
+      capabilities:
+        add:
+        - NET_ADMIN
+        - NET_BROADCAST
+        - NET_RAW
+    volumeMounts:
+    - mountPath: /usr/local/etc/keepalived/keepalived.conf
+      name: config
+    - mountPath: /etc/keepalived/check_apiserver.sh
+      name: check
+  hostNetwork: true
+  volumes:
+  - hostPath:
+      path: /etc/keepalived/keepalived.conf
+    name: config
+  - hostPath:
+      path: /etc/keepalived/check_apiserver.sh
+    name: check
+`))
+
+	keepalivedConfigTemplate = template.Must(template.New("keepalived.conf").Parse(`global_defs {
+  router_id LVS_DEVEL
+}
+vrrp_script check_apiserver {
+  script "/etc/keepalived/check_apiserver.sh"
+  interval 3
+  weight -2
+  fall 10
+  rise 2
+}
+vrrp_instance VI_1 {
+  state BACKUP
+  interface {{ .Interface }}
+  virtual_router_id {{ .VirtualRouterID }}
+  priority 100
+  virtual_ipaddress {
+    {{ .Address }}
+  }
+  track_script {
+    check_apiserver
+  }
+}
+`))
+
+	keepalivedCheckScriptTemplate = template.Must(template.New("check_apiserver.sh").Parse(`#!/bin/sh
+curl -sfk --max-time 2 https://localhost:{{ .Port }}/healthz -o /dev/null || {
+  echo "*** Error GET https://localhost:{{ .Port }}/healthz" 1>&2
+  exit 1
+}
+`))
+
+	haproxyManifestTemplate = template.Must(template.New("haproxy").Parse(`apiVersion: v1
+kind: Pod
+metadata:
+  name: haproxy
+  namespace: kube-system
+spec:
+  containers:
+  - name: haproxy
+    image: {{ .HAProxyImage }}
+    imagePullPolicy: IfNotPresent
+    livenessProbe:
+      failureThreshold: 8
+      httpGet:
+        host: localhost
+        path: /healthz
+        port: {{ .Port }}
+        scheme: HTTPS
+    volumeMounts:
+    - mountPath: /usr/local/etc/haproxy/haproxy.cfg
+      name: haproxyconf
+      readOnly: true
+  hostNetwork: true
+  volumes:
+  - hostPath:
+      path: /etc/haproxy/haproxy.cfg
+      type: FileOrCreate
+    name: haproxyconf
+`))
+
+	haproxyConfigTemplate = template.Must(template.New("haproxy.cfg").Parse(`global
+  log stdout format raw local0
+defaults
+  mode tcp
+  log global
+  option tcplog
+  option dontlognull
+  retries 1
+  timeout connect 5s
+  timeout client 35s
+  timeout server 35s
+  timeout check 10s
+frontend apiserver
+  bind *:{{ .Port }}
+  default_backend apiserverbackend
+backend apiserverbackend
+  option httpchk
+  http-check connect ssl
+  http-check send meth GET uri /healthz
+  http-check expect status 200
+  server apiserver 127.0.0.1:{{ .APIServerPort }} check verify none
+`))
+)
+
+// controlPlaneLoadBalancerFiles renders the static pod manifests and configuration files announcing the host of the
+// ControlPlaneEndpoint as a virtual IP. It returns no files if no ControlPlaneLoadBalancer is configured. kube-vip
+// uses the super-admin.conf on the machine running kubeadm init, as the admin.conf is only authorized once the
+// control plane is initialized.
+func controlPlaneLoadBalancerFiles(metalCluster *infrav1alpha1.IroncoreMetalCluster, kubeadmInit bool) ([]staticFile, error) {
+	loadBalancer := metalCluster.Spec.ControlPlaneLoadBalancer
+	if loadBalancer == nil {
+		return nil, nil
+	}
+
+	endpoint := metalCluster.Spec.ControlPlaneEndpoint
+	address := net.ParseIP(endpoint.Host)
+	if address == nil {
+		return nil, fmt.Errorf("control plane endpoint host %q is not an IP address", endpoint.Host)
+	}
+
+	data := loadBalancerTemplateData{
+		Address:         endpoint.Host,
+		AddressCIDR:     32,
+		Port:            endpoint.Port,
+		APIServerPort:   loadBalancer.APIServerPort,
+		Interface:       loadBalancer.Interface,
+		Image:           loadBalancer.Image,
+		HAProxyImage:    loadBalancer.HAProxyImage,
+		VirtualRouterID: loadBalancer.VirtualRouterID,
+		Kubeconfig:      adminKubeconfigPath,
+	}
+	if kubeadmInit {
+		data.Kubeconfig = superAdminKubeconfigPath
+	}
+	if address.To4() == nil {
+		data.AddressCIDR = 128
+	}
+	if data.APIServerPort == 0 {
+		data.APIServerPort = defaultLoadBalancerAPIServerPort
+	}
+	if data.VirtualRouterID == 0 {
+		data.VirtualRouterID = defaultVirtualRouterID
+	}
+	if data.HAProxyImage == "" {
+		data.HAProxyImage = defaultHAProxyImage
+	}
+
+	switch loadBalancer.Type {
+	case infrav1alpha1.ControlPlaneLoadBalancerKubeVIP:
+		if data.Image == "" {
+			data.Image = defaultKubeVIPImage
+		}
+		return renderStaticFiles(data, []staticFileTemplate{
+			{path: staticPodManifestsDir + "/kube-vip.yaml", mode: fileMode, template: kubeVIPManifestTemplate},
+		})
+	case infrav1alpha1.ControlPlaneLoadBalancerKeepalived:
+		if data.Image == "" {
+			data.Image = defaultKeepalivedImage
+		}
+		if data.Port == data.APIServerPort {
+			return nil, fmt.Errorf("port %d of the control plane endpoint conflicts with the kube-apiserver port", data.Port)
+		}
+		return renderStaticFiles(data, []staticFileTemplate{
+			{path: staticPodManifestsDir + "/keepalived.yaml", mode: fileMode, template: keepalivedManifestTemplate},
+			{path: "/etc/keepalived/keepalived.conf", mode: fileMode, template: keepalivedConfigTemplate},
+			{path: "/etc/keepalived/check_apiserver.sh", mode: scriptFileMode, template: keepalivedCheckScriptTemplate},
+			{path: staticPodManifestsDir + "/haproxy.yaml", mode: fileMode, template: haproxyManifestTemplate},
+			{path: "/etc/haproxy/haproxy.cfg", mode: fileMode, template: haproxyConfigTemplate},
+		})
+	default:
+		return nil, fmt.Errorf("unsupported control plane load balancer type %q", loadBalancer.Type)
+	}
+}
+
+// staticFileTemplate is the template of a staticFile.
+type staticFileTemplate struct {
+	path     string
+	mode     int
+	template *template.Template
+}
+
+func renderStaticFiles(data any, templates []staticFileTemplate) ([]staticFile, error) {
+	files := make([]staticFile, 0, len(templates))
+	for _, t := range templates {
+		var buf bytes.Buffer
+		if err := t.template.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", t.path, err)
+		}
+		files = append(files, staticFile{Path: t.path, Mode: t.mode, Data: buf.Bytes()})
+	}
+	return files, nil
+}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+)
+
+var _ = Describe("Control plane load balancer", func() {
+	metalCluster := &infrav1alpha1.IroncoreMetalCluster{
+		Spec: infrav1alpha1.IroncoreMetalClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "10.0.0.1", Port: 6443},
+			ControlPlaneLoadBalancer: &infrav1alpha1.ControlPlaneLoadBalancer{
+				Type:      infrav1alpha1.ControlPlaneLoadBalancerKubeVIP,
+				Interface: "eth0",
+			},
+		},
+	}
+
+	It("should mount the super-admin.conf into kube-vip on the machine running kubeadm init", func() {
+		files, err := controlPlaneLoadBalancerFiles(metalCluster, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+		Expect(string(files[0].Data)).To(ContainSubstring("path: /etc/kubernetes/super-admin.conf"))
+		Expect(string(files[0].Data)).To(ContainSubstring("mountPath: /etc/kubernetes/admin.conf"))
+	})
+
+	It("should mount the admin.conf into kube-vip on joining machines", func() {
+		files, err := controlPlaneLoadBalancerFiles(metalCluster, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(files[0].Data)).To(ContainSubstring("path: /etc/kubernetes/admin.conf"))
+		Expect(string(files[0].Data)).NotTo(ContainSubstring("super-admin.conf"))
+	})
+
+	It("should detect kubeadm init in plain and URL encoded bootstrap data", func() {
+		Expect(isKubeadmInit([]byte("runcmd:\n  - kubeadm init --config /run/kubeadm/kubeadm.yaml"))).To(BeTrue())
+		Expect(isKubeadmInit([]byte(`"source":"data:,kubeadm%20init%20--config%20%2Fetc%2Fkubeadm.yml"`))).To(BeTrue())
+		Expect(isKubeadmInit([]byte("runcmd:\n  - kubeadm join --config /run/kubeadm/kubeadm-join-config.yaml"))).To(BeFalse())
+		Expect(isKubeadmInit([]byte("kubeadm initialize"))).To(BeFalse())
+	})
+})
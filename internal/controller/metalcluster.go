@@ -0,0 +1,324 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/scope"
+	"github.com/ironcore-dev/controller-utils/clientutils"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// metalClusterSyncTimeout bounds the initial sync of the cache of a remote cluster, e.g. if it is not reachable.
+const metalClusterSyncTimeout = time.Minute
+
+// MetalClusters caches the remote clusters running the metal-operator which are referenced by the
+// MetalKubeconfigSecretRef of the IroncoreMetalClusters. It is shared by the reconcilers. A cluster is started on first
+// use with a cache of its Servers and of the objects in the TargetNamespace, restarted once its kubeconfig secret
+// changes and stopped once the last IroncoreMetalCluster referencing it is deleted. The watches registered by the
+// controllers are added to every started cluster.
+type MetalClusters struct {
+	// mu guards the map of the clusters and the watches. It is not held while a cluster is started, which is
+	// serialised per cluster by the mutex of its entry.
+	mu       sync.Mutex
+	clusters map[metalClusterKey]*metalClusterEntry
+	watches  []func(cluster.Cluster) error
+}
+
+// metalClusterKey identifies a remote cluster by its kubeconfig secret and the namespace its cache is restricted to.
+type metalClusterKey struct {
+	secret    client.ObjectKey
+	namespace string
+}
+
+// metalClusterEntry is the started cluster of a metalClusterKey. Its fields are written while holding both mutexes.
+type metalClusterEntry struct {
+	mu              sync.Mutex
+	evicted         bool
+	resourceVersion string
+	cluster         cluster.Cluster
+	cancel          context.CancelFunc
+}
+
+// metalClusterKeyOf returns the key of the remote cluster referenced by the IroncoreMetalCluster.
+func metalClusterKeyOf(metalCluster *infrav1alpha1.IroncoreMetalCluster) metalClusterKey {
+	ref := metalCluster.Spec.MetalKubeconfigSecretRef
+	return metalClusterKey{
+		secret:    client.ObjectKey{Namespace: metalCluster.Namespace, Name: ref.Name},
+		namespace: cmp.Or(ref.TargetNamespace, metalCluster.Namespace),
+	}
+}
+
+// addWatches registers the watches of a controller on the objects of the remote clusters. They are added to the
+// clusters which are already started and to every cluster started later.
+func (m *MetalClusters) addWatches(watches func(cluster.Cluster) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, entry := range m.clusters {
+		if entry.cluster == nil {
+			continue
+		}
+		if err := watches(entry.cluster); err != nil {
+			return err
+		}
+	}
+	m.watches = append(m.watches, watches)
+	return nil
+}
+
+// entry returns the entry of the key, which is added if it does not exist yet.
+func (m *MetalClusters) entry(key metalClusterKey) *metalClusterEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.clusters[key]
+	if !ok {
+		if m.clusters == nil {
+			m.clusters = make(map[metalClusterKey]*metalClusterEntry)
+		}
+		entry = &metalClusterEntry{}
+		m.clusters[key] = entry
+	}
+	return entry
+}
+
+// cluster returns the started cluster of the key. A cluster started from a previous version of the kubeconfig secret
+// is stopped, the watches on its cache stop with it. Only the reconciliations of the same key wait for a cluster
+// which is being started.
+func (m *MetalClusters) cluster(ctx context.Context, c client.Client, key metalClusterKey) (cluster.Cluster, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, key.secret, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the metal kubeconfig secret %s: %w", key.secret.Name, err)
+	}
+
+	entry := m.entry(key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.evicted {
+		return nil, fmt.Errorf("the metal cluster of secret %s has been stopped", key.secret.Name)
+	}
+	if entry.cluster != nil && entry.resourceVersion == secret.ResourceVersion {
+		return entry.cluster, nil
+	}
+
+	metalCluster, cancel, err := m.startCluster(ctx, c, key, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, watches := range m.watches {
+		if err := watches(metalCluster); err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to watch the metal cluster of secret %s: %w", key.secret.Name, err)
+		}
+	}
+	if entry.cancel != nil {
+		entry.cancel()
+	}
+	entry.resourceVersion, entry.cluster, entry.cancel = secret.ResourceVersion, metalCluster, cancel
+	return metalCluster, nil
+}
+
+// startCluster starts the cluster of the kubeconfig in secret and waits for the initial sync of its cache. The cluster
+// outlives the reconciliation which started it, it is stopped with the returned cancel func.
+func (m *MetalClusters) startCluster(ctx context.Context, c client.Client, key metalClusterKey, secret *corev1.Secret) (cluster.Cluster, context.CancelFunc, error) {
+	kubeconfig, ok := secret.Data[infrav1alpha1.MetalKubeconfigSecretKey]
+	if !ok {
+		return nil, nil, fmt.Errorf("metal kubeconfig secret %s has no %q key", key.secret.Name, infrav1alpha1.MetalKubeconfigSecretKey)
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the metal kubeconfig of secret %s: %w", key.secret.Name, err)
+	}
+	metalCluster, err := cluster.New(restConfig, func(o *cluster.Options) {
+		o.Scheme = c.Scheme()
+		o.Cache.DefaultNamespaces = map[string]cache.Config{key.namespace: {}}
+		// the ignition secrets are read directly instead of caching every secret of the namespace
+		o.Client.Cache = &client.CacheOptions{DisableFor: []client.Object{&corev1.Secret{}}}
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the client of the metal cluster: %w", err)
+	}
+
+	clusterCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		if err := metalCluster.Start(clusterCtx); err != nil {
+			log.FromContext(ctx).Error(err, "failed to start the metal cluster", "Secret", key.secret)
+		}
+	}()
+	syncCtx, cancelSync := context.WithTimeout(ctx, metalClusterSyncTimeout)
+	defer cancelSync()
+	for _, obj := range []client.Object{&metalv1alpha1.ServerClaim{}, &metalv1alpha1.Server{}, &metalv1alpha1.ServerMaintenance{}} {
+		if _, err := metalCluster.GetCache().GetInformer(syncCtx, obj); err != nil {
+			cancel()
+			return nil, nil, fmt.Errorf("failed to sync the cache of the metal cluster of secret %s: %w", key.secret.Name, err)
+		}
+	}
+	return metalCluster, cancel, nil
+}
+
+// release stops and evicts the cluster of a deleted IroncoreMetalCluster unless another IroncoreMetalCluster still
+// references it.
+func (m *MetalClusters) release(ctx context.Context, c client.Client, metalCluster *infrav1alpha1.IroncoreMetalCluster) error {
+	if m == nil || metalCluster.Spec.MetalKubeconfigSecretRef == nil {
+		return nil
+	}
+	key := metalClusterKeyOf(metalCluster)
+
+	metalClusters := &infrav1alpha1.IroncoreMetalClusterList{}
+	if err := c.List(ctx, metalClusters, client.InNamespace(metalCluster.Namespace)); err != nil {
+		return fmt.Errorf("failed to list IroncoreMetalClusters: %w", err)
+	}
+	for _, other := range metalClusters.Items {
+		if other.UID != metalCluster.UID && other.Spec.MetalKubeconfigSecretRef != nil && metalClusterKeyOf(&other) == key {
+			return nil
+		}
+	}
+
+	m.mu.Lock()
+	entry, ok := m.clusters[key]
+	delete(m.clusters, key)
+	m.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	entry.evicted = true
+	if entry.cancel != nil {
+		entry.cancel()
+	}
+	return nil
+}
+
+// metalClusterClient returns the client and the namespace of the ServerClaims of the IroncoreMetalCluster. Without a
+// MetalKubeconfigSecretRef these are the client c and the namespace of the IroncoreMetalCluster, otherwise the cached
+// client of the remote cluster in metalClusters.
+func metalClusterClient(ctx context.Context, c client.Client, metalClusters *MetalClusters, metalCluster *infrav1alpha1.IroncoreMetalCluster) (client.Client, string, error) {
+	if metalCluster.Spec.MetalKubeconfigSecretRef == nil {
+		return c, metalCluster.Namespace, nil
+	}
+	key := metalClusterKeyOf(metalCluster)
+
+	remoteCluster, err := metalClusters.cluster(ctx, c, key)
+	if err != nil {
+		return nil, "", err
+	}
+	return remoteCluster.GetClient(), key.namespace, nil
+}
+
+// metalObjectName returns the name of an object of the IroncoreMetalMachine in the cluster of the MetalClient, e.g. of
+// its ServerClaim. The IroncoreMetalMachines of several namespaces may share the TargetNamespace of a remote cluster,
+// so the name is qualified with the namespace of the IroncoreMetalMachine and suffixed with a hash of both there.
+func metalObjectName(machineScope *scope.MachineScope, prefix string) string {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+	if !machineScope.RemoteMetalCluster() {
+		return prefix + ironcoremetalmachine.Name
+	}
+	hash := nameHash(ironcoremetalmachine.Namespace + "/" + ironcoremetalmachine.Name)
+	return truncateWithHash(fmt.Sprintf("%s%s-%s-%s", prefix, ironcoremetalmachine.Namespace, ironcoremetalmachine.Name, hash), validation.DNS1123SubdomainMaxLength)
+}
+
+// serverClaimKey returns the key of the ServerClaim of the IroncoreMetalMachine in the cluster of the MetalClient.
+func serverClaimKey(machineScope *scope.MachineScope) client.ObjectKey {
+	return client.ObjectKey{Namespace: machineScope.MetalNamespace, Name: metalObjectName(machineScope, "")}
+}
+
+// machineProviderID returns the ProviderID of the IroncoreMetalMachine, which references its ServerClaim in the cluster
+// of the MetalClient. It is also passed to the bootstrap data as METAL_PROVIDER_ID.
+func machineProviderID(machineScope *scope.MachineScope) string {
+	key := serverClaimKey(machineScope)
+	return fmt.Sprintf("metal://%s/%s", key.Namespace, key.Name)
+}
+
+// metalObjectLabels returns the labels identifying the IroncoreMetalMachine an object in the cluster of the
+// MetalClient belongs to.
+func metalObjectLabels(ironcoremetalmachine *infrav1alpha1.IroncoreMetalMachine) map[string]string {
+	return map[string]string{
+		LabelKeyServerClaimName:      truncateWithHash(ironcoremetalmachine.Name, validation.LabelValueMaxLength),
+		LabelKeyServerClaimNamespace: ironcoremetalmachine.Namespace,
+	}
+}
+
+// ownsMetalObject reports whether an object in the cluster of the MetalClient belongs to the IroncoreMetalMachine. In
+// the management cluster the name identifies the IroncoreMetalMachine, in a remote cluster the metalObjectLabels have
+// to match as well.
+func ownsMetalObject(machineScope *scope.MachineScope, obj client.Object) bool {
+	if !machineScope.RemoteMetalCluster() {
+		return true
+	}
+	for key, value := range metalObjectLabels(machineScope.IroncoreMetalMachine) {
+		if obj.GetLabels()[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// getMetalObject gets an object of the IroncoreMetalMachine from the cluster of the MetalClient. An object belonging to
+// another IroncoreMetalMachine is reported as not found, so it is neither adopted, patched nor deleted.
+func getMetalObject(ctx context.Context, machineScope *scope.MachineScope, key client.ObjectKey, obj client.Object) error {
+	if err := machineScope.MetalClient.Get(ctx, key, obj); err != nil {
+		return err
+	}
+	if !ownsMetalObject(machineScope, obj) {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	return nil
+}
+
+// reconcileMetalClusterUnavailable surfaces that the metal cluster of the IroncoreMetalMachine can not be reached. The
+// MetalClient of the machineScope must not be used, it defaults to the client of the management cluster. A deleting
+// IroncoreMetalMachine is only released if its IroncoreMetalCluster has the OrphanMetalObjectsAnnotation, its
+// ServerClaim and ignition secret are left behind then.
+func (r *IroncoreMetalMachineReconciler) reconcileMetalClusterUnavailable(ctx context.Context, machineScope *scope.MachineScope, metalClusterErr error) (ctrl.Result, error) {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+	conditions.Set(ironcoremetalmachine, metav1.Condition{
+		Type:    infrav1alpha1.ServerClaimBoundCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  infrav1alpha1.MetalClusterUnavailableReason,
+		Message: metalClusterErr.Error(),
+	})
+	if ironcoremetalmachine.DeletionTimestamp.IsZero() ||
+		!metav1.HasAnnotation(machineScope.IroncoreMetalCluster.ObjectMeta, infrav1alpha1.OrphanMetalObjectsAnnotation) {
+		return ctrl.Result{}, metalClusterErr
+	}
+
+	ipAddressClaimsDeleted, err := r.ensureIPAddressClaimsDeleted(ctx, machineScope)
+	if err != nil {
+		machineScope.Error(err, "failed to delete IPAddressClaims")
+		return ctrl.Result{}, err
+	}
+	if !ipAddressClaimsDeleted {
+		machineScope.Info("Waiting for IPAddressClaims to be deleted")
+		return ctrl.Result{RequeueAfter: infrav1alpha1.DefaultReconcilerRequeue}, nil
+	}
+
+	record.Warnf(ironcoremetalmachine, infrav1alpha1.MetalObjectsOrphanedReason,
+		"Leaving the ServerClaim and the ignition secret behind in the unavailable metal cluster: %v", metalClusterErr)
+	if modified, err := clientutils.PatchEnsureNoFinalizer(ctx, r.Client, ironcoremetalmachine, IroncoreMetalMachineFinalizer); !apierrors.IsNotFound(err) || modified {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,444 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"net/netip"
+	"path"
+	"slices"
+	"strconv"
+	"text/template"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	networkManagerConnectionsDir = "/etc/NetworkManager/system-connections"
+	networkManagerFileMode       = 0600
+
+	networkInterfaceTypeEthernet = "ethernet"
+	networkInterfaceTypeBond     = "bond"
+	networkInterfaceTypeVLAN     = "vlan"
+
+	defaultNetworkBondMode = "active-backup"
+)
+
+// networkInterfaceData is passed to the templates of the network configuration files of an interface.
+type networkInterfaceData struct {
+	Name       string
+	Type       string
+	MACAddress string
+	MTU        int32
+	DHCP       bool
+	Bond       string
+	BondMode   string
+	VLANID     int32
+	VLANLink   string
+	VLANs      []string
+	Addresses  []netip.Prefix
+	Gateways   []netip.Addr
+	Routes     []networkRouteData
+}
+
+// networkRouteData is a static route of an interface.
+type networkRouteData struct {
+	To     netip.Prefix
+	Via    string
+	Metric int32
+}
+
+// Keyfile returns the route in the format of a NetworkManager keyfile. A route without gateway is set to the
+// unspecified address if it has a metric.
+func (r networkRouteData) Keyfile() string {
+	via := r.Via
+	if via == "" && r.Metric != 0 {
+		via = netip.IPv6Unspecified().String()
+		if r.To.Addr().Is4() {
+			via = netip.IPv4Unspecified().String()
+		}
+	}
+
+	value := r.To.String()
+	if via != "" {
+		value += "," + via
+	}
+	if r.Metric != 0 {
+		value += "," + strconv.Itoa(int(r.Metric))
+	}
+	return value
+}
+
+// networkManagerFamilyData is the ipv4 or ipv6 section of a NetworkManager keyfile.
+type networkManagerFamilyData struct {
+	Method    string
+	Addresses []netip.Prefix
+	Gateway   string
+	Routes    []networkRouteData
+}
+
+// networkManagerConnectionData is passed to the NetworkManager keyfile template.
+type networkManagerConnectionData struct {
+	networkInterfaceData
+	IPv4 networkManagerFamilyData
+	IPv6 networkManagerFamilyData
+}
+
+var (
+	networkdLinkTemplate = template.Must(template.New("link").Parse(`[Match]
+MACAddress={{ .MACAddress }}
+
+[Link]
+Name={{ .Name }}
+`))
+
+	networkdNetDevTemplate = template.Must(template.New("netdev").Parse(`[NetDev]
+Name={{ .Name }}
+Kind={{ .Type }}
+{{- if eq .Type "bond" }}
+
+[Bond]
+Mode={{ .BondMode }}
+MIIMonitorSec=100ms
+{{- end }}
+{{- if eq .Type "vlan" }}
+
+[VLAN]
+Id={{ .VLANID }}
+{{- end }}
+`))
+
+	networkdNetworkTemplate = template.Must(template.New("network").Parse(`[Match]
+Name={{ .Name }}
+{{- if .MTU }}
+
+[Link]
+MTUBytes={{ .MTU }}
+{{- end }}
+
+[Network]
+{{- if .Bond }}
+Bond={{ .Bond }}
+{{- else }}
+DHCP={{ if .DHCP }}yes{{ else }}no{{ end }}
+{{- end }}
+{{- range .VLANs }}
+VLAN={{ . }}
+{{- end }}
+{{- range .Addresses }}
+Address={{ . }}
+{{- end }}
+{{- range .Gateways }}
+Gateway={{ . }}
+{{- end }}
+{{- range .Routes }}
+
+[Route]
+Destination={{ .To }}
+{{- if .Via }}
+Gateway={{ .Via }}
+{{- end }}
+{{- if .Metric }}
+Metric={{ .Metric }}
+{{- end }}
+{{- end }}
+`))
+
+	networkManagerConnectionTemplate = template.Must(template.New("nmconnection").Funcs(template.FuncMap{
+		"inc": func(i int) int { return i + 1 },
+	}).Parse(`[connection]
+id={{ .Name }}
+type={{ .Type }}
+interface-name={{ .Name }}
+{{- if .Bond }}
+master={{ .Bond }}
+slave-type=bond
+{{- end }}
+{{- if .MTU }}
+
+[ethernet]
+mtu={{ .MTU }}
+{{- end }}
+{{- if eq .Type "bond" }}
+
+[bond]
+mode={{ .BondMode }}
+miimon=100
+{{- end }}
+{{- if eq .Type "vlan" }}
+
+[vlan]
+id={{ .VLANID }}
+parent={{ .VLANLink }}
+{{- end }}
+{{- if not .Bond }}
+{{- with .IPv4 }}
+
+[ipv4]
+method={{ .Method }}
+{{- range $i, $address := .Addresses }}
+address{{ inc $i }}={{ $address }}
+{{- end }}
+{{- if .Gateway }}
+gateway={{ .Gateway }}
+{{- end }}
+{{- range $i, $route := .Routes }}
+route{{ inc $i }}={{ $route.Keyfile }}
+{{- end }}
+{{- end }}
+{{- with .IPv6 }}
+
+[ipv6]
+method={{ .Method }}
+{{- range $i, $address := .Addresses }}
+address{{ inc $i }}={{ $address }}
+{{- end }}
+{{- if .Gateway }}
+gateway={{ .Gateway }}
+{{- end }}
+{{- range $i, $route := .Routes }}
+route{{ inc $i }}={{ $route.Keyfile }}
+{{- end }}
+{{- end }}
+{{- end }}
+`))
+)
+
+// networkConfigFiles renders the NetworkConfig into systemd-networkd units or NetworkManager keyfiles. The addresses
+// of the interfaces are taken from the metadata of the IPAddresses allocated for the MetadataKeys of the IPAMConfig.
+// It returns no files if no NetworkConfig is configured.
+func networkConfigFiles(networkConfig *infrav1alpha1.NetworkConfig, ipAddressesMetadata map[string]any) ([]staticFile, error) {
+	if networkConfig == nil {
+		return nil, nil
+	}
+
+	interfaces, err := networkInterfaces(networkConfig, ipAddressesMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []staticFile
+	for _, iface := range interfaces {
+		if iface.MACAddress != "" {
+			// the interface is renamed by udev, which is used by both renderers
+			linkFiles, err := renderStaticFiles(iface, []staticFileTemplate{
+				{path: path.Join(systemdNetworkDir, "10-"+iface.Name+".link"), mode: fileMode, template: networkdLinkTemplate},
+			})
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, linkFiles...)
+		}
+
+		var interfaceFiles []staticFile
+		switch networkConfig.Renderer {
+		case infrav1alpha1.NetworkRendererNetworkd, "":
+			templates := []staticFileTemplate{
+				{path: path.Join(systemdNetworkDir, "20-"+iface.Name+".network"), mode: fileMode, template: networkdNetworkTemplate},
+			}
+			if iface.Type != networkInterfaceTypeEthernet {
+				templates = append([]staticFileTemplate{
+					{path: path.Join(systemdNetworkDir, "20-"+iface.Name+".netdev"), mode: fileMode, template: networkdNetDevTemplate},
+				}, templates...)
+			}
+			interfaceFiles, err = renderStaticFiles(iface, templates)
+		case infrav1alpha1.NetworkRendererNetworkManager:
+			interfaceFiles, err = renderStaticFiles(networkManagerConnectionData{
+				networkInterfaceData: iface,
+				IPv4:                 networkManagerFamily(iface, true),
+				IPv6:                 networkManagerFamily(iface, false),
+			}, []staticFileTemplate{
+				{path: path.Join(networkManagerConnectionsDir, iface.Name+".nmconnection"), mode: networkManagerFileMode, template: networkManagerConnectionTemplate},
+			})
+		default:
+			return nil, fmt.Errorf("unsupported network renderer %q", networkConfig.Renderer)
+		}
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, interfaceFiles...)
+	}
+	return files, nil
+}
+
+// networkInterfaces resolves the Ethernets, Bonds and VLANs of the NetworkConfig into the data of their configuration
+// files, including the bond and VLAN relations and the addresses allocated for their MetadataKeys.
+func networkInterfaces(networkConfig *infrav1alpha1.NetworkConfig, ipAddressesMetadata map[string]any) ([]networkInterfaceData, error) {
+	var interfaces []networkInterfaceData
+	add := func(iface infrav1alpha1.NetworkInterface, data networkInterfaceData) error {
+		if slices.ContainsFunc(interfaces, func(i networkInterfaceData) bool { return i.Name == iface.Name }) {
+			return fmt.Errorf("network interface %s is configured more than once", iface.Name)
+		}
+		data.Name = iface.Name
+		data.MTU = iface.MTU
+		data.DHCP = iface.DHCP
+		for _, key := range iface.AddressesFrom {
+			addresses, gateways, err := networkAddresses(ipAddressesMetadata, key)
+			if err != nil {
+				return fmt.Errorf("failed to get addresses of network interface %s: %w", iface.Name, err)
+			}
+			data.Addresses = append(data.Addresses, addresses...)
+			for _, gateway := range gateways {
+				if !slices.Contains(data.Gateways, gateway) {
+					data.Gateways = append(data.Gateways, gateway)
+				}
+			}
+		}
+		for _, route := range iface.Routes {
+			to, err := netip.ParsePrefix(route.To)
+			if err != nil {
+				return fmt.Errorf("invalid destination of a route of network interface %s: %w", iface.Name, err)
+			}
+			data.Routes = append(data.Routes, networkRouteData{To: to, Via: route.Via, Metric: ptr.Deref(route.Metric, 0)})
+		}
+		interfaces = append(interfaces, data)
+		return nil
+	}
+
+	for _, ethernet := range networkConfig.Ethernets {
+		if err := add(ethernet.NetworkInterface, networkInterfaceData{
+			Type:       networkInterfaceTypeEthernet,
+			MACAddress: ethernet.MACAddress,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	for _, bond := range networkConfig.Bonds {
+		mode := string(bond.Mode)
+		if mode == "" {
+			mode = defaultNetworkBondMode
+		}
+		if err := add(bond.NetworkInterface, networkInterfaceData{Type: networkInterfaceTypeBond, BondMode: mode}); err != nil {
+			return nil, err
+		}
+	}
+	for _, vlan := range networkConfig.VLANs {
+		if err := add(vlan.NetworkInterface, networkInterfaceData{
+			Type:     networkInterfaceTypeVLAN,
+			VLANID:   vlan.ID,
+			VLANLink: vlan.Link,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	lookup := func(name string) *networkInterfaceData {
+		for i := range interfaces {
+			if interfaces[i].Name == name {
+				return &interfaces[i]
+			}
+		}
+		return nil
+	}
+	for _, bond := range networkConfig.Bonds {
+		for _, member := range bond.Interfaces {
+			iface := lookup(member)
+			if iface == nil || iface.Type != networkInterfaceTypeEthernet {
+				return nil, fmt.Errorf("interface %s of bond %s is not an ethernet", member, bond.Name)
+			}
+			if iface.Bond != "" {
+				return nil, fmt.Errorf("ethernet %s is a member of the bonds %s and %s", member, iface.Bond, bond.Name)
+			}
+			iface.Bond = bond.Name
+		}
+	}
+	for _, vlan := range networkConfig.VLANs {
+		link := lookup(vlan.Link)
+		if link == nil || link.Type == networkInterfaceTypeVLAN {
+			return nil, fmt.Errorf("link %s of vlan %s is not an ethernet or bond", vlan.Link, vlan.Name)
+		}
+		link.VLANs = append(link.VLANs, vlan.Name)
+	}
+
+	return interfaces, nil
+}
+
+// networkAddresses returns the addresses and gateways allocated for a MetadataKey of the IPAMConfig.
+func networkAddresses(ipAddressesMetadata map[string]any, key string) ([]netip.Prefix, []netip.Addr, error) {
+	ipAddress, ok := ipAddressesMetadata[key].(map[string]any)
+	if !ok {
+		return nil, nil, fmt.Errorf("no IP addresses allocated for metadata key %s", key)
+	}
+	ipAddresses, _ := ipAddress["addresses"].([]any)
+	if len(ipAddresses) == 0 {
+		ipAddresses = []any{ipAddress}
+	}
+
+	var addresses []netip.Prefix
+	var gateways []netip.Addr
+	for _, a := range ipAddresses {
+		address, _ := a.(map[string]any)
+		ip, _ := address["ip"].(string)
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid IP address %q for metadata key %s: %w", ip, key, err)
+		}
+		bits := addr.BitLen()
+		if prefix, ok := metadataPrefix(address["prefix"]); ok {
+			bits = prefix
+		}
+		addresses = append(addresses, netip.PrefixFrom(addr, bits))
+
+		gateway, _ := address["gateway"].(string)
+		if gw, err := netip.ParseAddr(gateway); err == nil && !slices.Contains(gateways, gw) {
+			gateways = append(gateways, gw)
+		}
+	}
+	return addresses, gateways, nil
+}
+
+// metadataPrefix returns the prefix length of an IP address in the metadata, which is an *int32 as long as the
+// metadata was not serialized.
+func metadataPrefix(prefix any) (int, bool) {
+	switch p := prefix.(type) {
+	case *int32:
+		if p != nil {
+			return int(*p), true
+		}
+	case int32:
+		return int(p), true
+	case int:
+		return p, true
+	case float64:
+		return int(p), true
+	case string:
+		if i, err := strconv.Atoi(p); err == nil {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// networkManagerFamily returns the ipv4 or ipv6 section of the NetworkManager keyfile of an interface.
+func networkManagerFamily(iface networkInterfaceData, ipv4 bool) networkManagerFamilyData {
+	family := networkManagerFamilyData{}
+	for _, address := range iface.Addresses {
+		if address.Addr().Is4() == ipv4 {
+			family.Addresses = append(family.Addresses, address)
+		}
+	}
+	for _, gateway := range iface.Gateways {
+		if gateway.Is4() == ipv4 && family.Gateway == "" {
+			family.Gateway = gateway.String()
+		}
+	}
+	for _, route := range iface.Routes {
+		if route.To.Addr().Is4() == ipv4 {
+			family.Routes = append(family.Routes, route)
+		}
+	}
+
+	switch {
+	case iface.DHCP:
+		family.Method = "auto"
+	case len(family.Addresses) > 0:
+		family.Method = "manual"
+	case ipv4:
+		family.Method = "disabled"
+	default:
+		family.Method = "ignore"
+	}
+	return family
+}
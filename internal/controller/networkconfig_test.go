@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Network config", func() {
+	ipAddressesMetadata := map[string]any{
+		"net": map[string]any{
+			"addresses": []any{
+				map[string]any{"ip": "10.0.0.2", "prefix": ptr.To[int32](24), "gateway": "10.0.0.1", "family": "IPv4"},
+				map[string]any{"ip": "2001:db8::2", "prefix": ptr.To[int32](64), "gateway": "2001:db8::1", "family": "IPv6"},
+			},
+		},
+	}
+
+	networkConfig := func(renderer infrav1alpha1.NetworkRenderer) *infrav1alpha1.NetworkConfig {
+		return &infrav1alpha1.NetworkConfig{
+			Renderer: renderer,
+			Ethernets: []infrav1alpha1.NetworkEthernet{
+				{NetworkInterface: infrav1alpha1.NetworkInterface{Name: "eth0"}, MACAddress: "aa:bb:cc:dd:ee:ff"},
+				{NetworkInterface: infrav1alpha1.NetworkInterface{Name: "eth1"}},
+			},
+			Bonds: []infrav1alpha1.NetworkBond{{
+				NetworkInterface: infrav1alpha1.NetworkInterface{Name: "bond0", MTU: 9000},
+				Interfaces:       []string{"eth0", "eth1"},
+			}},
+			VLANs: []infrav1alpha1.NetworkVLAN{{
+				NetworkInterface: infrav1alpha1.NetworkInterface{
+					Name:          "vlan100",
+					AddressesFrom: []string{"net"},
+					Routes:        []infrav1alpha1.NetworkRoute{{To: "172.16.0.0/12", Metric: ptr.To[int32](100)}},
+				},
+				ID:   100,
+				Link: "bond0",
+			}},
+		}
+	}
+
+	fileData := func(files []staticFile, path string) string {
+		for _, file := range files {
+			if file.Path == path {
+				return string(file.Data)
+			}
+		}
+		return ""
+	}
+
+	It("should render no files without a network config", func() {
+		Expect(networkConfigFiles(nil, ipAddressesMetadata)).To(BeEmpty())
+	})
+
+	It("should render systemd-networkd units", func() {
+		files, err := networkConfigFiles(networkConfig(infrav1alpha1.NetworkRendererNetworkd), ipAddressesMetadata)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(files).To(HaveLen(7))
+		Expect(fileData(files, "/etc/systemd/network/10-eth0.link")).To(Equal(`[Match]
+MACAddress=aa:bb:cc:dd:ee:ff
+
+[Link]
+Name=eth0
+`))
+		Expect(fileData(files, "/etc/systemd/network/20-eth1.network")).To(Equal(`[Match]
+Name=eth1
+
+[Network]
+Bond=bond0
+`))
+		Expect(fileData(files, "/etc/systemd/network/20-bond0.netdev")).To(Equal(`[NetDev]
+Name=bond0
+Kind=bond
+
+[Bond]
+Mode=active-backup
+MIIMonitorSec=100ms
+`))
+		Expect(fileData(files, "/etc/systemd/network/20-bond0.network")).To(Equal(`[Match]
+Name=bond0
+
+[Link]
+MTUBytes=9000
+
+[Network]
+DHCP=no
+VLAN=vlan100
+`))
+		Expect(fileData(files, "/etc/systemd/network/20-vlan100.netdev")).To(Equal(`[NetDev]
+Name=vlan100
+Kind=vlan
+
+[VLAN]
+Id=100
+`))
+		Expect(fileData(files, "/etc/systemd/network/20-vlan100.network")).To(Equal(`[Match]
+Name=vlan100
+
+[Network]
+DHCP=no
+Address=10.0.0.2/24
+Address=2001:db8::2/64
+Gateway=10.0.0.1
+Gateway=2001:db8::1
+
+[Route]
+Destination=172.16.0.0/12
+Metric=100
+`))
+	})
+
+	It("should render NetworkManager keyfiles", func() {
+		files, err := networkConfigFiles(networkConfig(infrav1alpha1.NetworkRendererNetworkManager), ipAddressesMetadata)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(files).To(HaveLen(5))
+		Expect(files).To(ContainElement(HaveField("Path", "/etc/systemd/network/10-eth0.link")))
+		Expect(files).To(HaveEach(Or(HaveField("Mode", networkManagerFileMode), HaveField("Path", HaveSuffix(".link")))))
+		Expect(fileData(files, "/etc/NetworkManager/system-connections/eth0.nmconnection")).To(Equal(`[connection]
+id=eth0
+type=ethernet
+interface-name=eth0
+master=bond0
+slave-type=bond
+`))
+		Expect(fileData(files, "/etc/NetworkManager/system-connections/vlan100.nmconnection")).To(Equal(`[connection]
+id=vlan100
+type=vlan
+interface-name=vlan100
+
+[vlan]
+id=100
+parent=bond0
+
+[ipv4]
+method=manual
+address1=10.0.0.2/24
+gateway=10.0.0.1
+route1=172.16.0.0/12,0.0.0.0,100
+
+[ipv6]
+method=manual
+address1=2001:db8::2/64
+gateway=2001:db8::1
+`))
+	})
+
+	It("should fail if no addresses are allocated for a metadata key", func() {
+		config := networkConfig(infrav1alpha1.NetworkRendererNetworkd)
+		config.VLANs[0].AddressesFrom = []string{"other"}
+
+		_, err := networkConfigFiles(config, ipAddressesMetadata)
+		Expect(err).To(MatchError(ContainSubstring("no IP addresses allocated for metadata key other")))
+	})
+
+	It("should fail if a bond aggregates an unknown interface", func() {
+		config := networkConfig(infrav1alpha1.NetworkRendererNetworkd)
+		config.Bonds[0].Interfaces = append(config.Bonds[0].Interfaces, "eth2")
+
+		_, err := networkConfigFiles(config, ipAddressesMetadata)
+		Expect(err).To(MatchError("interface eth2 of bond bond0 is not an ethernet"))
+	})
+})
@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/go-logr/logr"
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	capiv1beta2 "sigs.k8s.io/cluster-api/api/ipam/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationKeyDNSNameservers overrides the nameservers of the network of an IPAM pool or IPAddress. The value is
+	// a comma-separated list of IP addresses.
+	AnnotationKeyDNSNameservers = "metal.ironcore.dev/dns-nameservers"
+	// AnnotationKeyDNSSearchDomains overrides the search domains of the network of an IPAM pool or IPAddress. The
+	// value is a comma-separated list of domains.
+	AnnotationKeyDNSSearchDomains = "metal.ironcore.dev/dns-search-domains"
+	// AnnotationKeyNTPServers overrides the NTP servers of the network of an IPAM pool or IPAddress. The value is a
+	// comma-separated list of IP addresses or hostnames.
+	AnnotationKeyNTPServers = "metal.ironcore.dev/ntp-servers"
+
+	// The metadata keys are camel case to not collide with the MetadataKeys of the IPAMConfig.
+	metadataKeyDNSNameservers   = "dnsNameservers"
+	metadataKeyDNSSearchDomains = "dnsSearchDomains"
+	metadataKeyNTPServers       = "ntpServers"
+
+	resolvedDropInFile  = "/etc/systemd/resolved.conf.d/90-metal.conf"
+	timesyncdDropInFile = "/etc/systemd/timesyncd.conf.d/90-metal.conf"
+)
+
+var (
+	resolvedDropInTemplate = template.Must(template.New("resolved.conf").Parse(`[Resolve]
+{{- if .Nameservers }}
+DNS={{ range $i, $s := .Nameservers }}{{ if $i }} {{ end }}{{ $s }}{{ end }}
+{{- end }}
+{{- if .SearchDomains }}
+Domains={{ range $i, $s := .SearchDomains }}{{ if $i }} {{ end }}{{ $s }}{{ end }}
+{{- end }}
+`))
+
+	timesyncdDropInTemplate = template.Must(template.New("timesyncd.conf").Parse(`[Time]
+NTP={{ range $i, $s := .NTPServers }}{{ if $i }} {{ end }}{{ $s }}{{ end }}
+`))
+)
+
+// networkServices are the DNS and NTP servers of a network.
+type networkServices struct {
+	Nameservers   []string
+	SearchDomains []string
+	NTPServers    []string
+}
+
+// networkServicesFromAnnotations returns the networkServices an IPAM pool or IPAddress is annotated with.
+func networkServicesFromAnnotations(annotations map[string]string) networkServices {
+	split := func(key string) []string {
+		var values []string
+		for value := range strings.SplitSeq(annotations[key], ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	return networkServices{
+		Nameservers:   split(AnnotationKeyDNSNameservers),
+		SearchDomains: split(AnnotationKeyDNSSearchDomains),
+		NTPServers:    split(AnnotationKeyNTPServers),
+	}
+}
+
+// withDefaults returns the networkServices with the unset fields taken from defaults.
+func (s networkServices) withDefaults(defaults networkServices) networkServices {
+	if len(s.Nameservers) == 0 {
+		s.Nameservers = defaults.Nameservers
+	}
+	if len(s.SearchDomains) == 0 {
+		s.SearchDomains = defaults.SearchDomains
+	}
+	if len(s.NTPServers) == 0 {
+		s.NTPServers = defaults.NTPServers
+	}
+	return s
+}
+
+// union returns the networkServices with the values of other appended, without duplicates.
+func (s networkServices) union(other networkServices) networkServices {
+	appendUnique := func(values, others []string) []string {
+		values = slices.Clone(values)
+		for _, value := range others {
+			if !slices.Contains(values, value) {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	return networkServices{
+		Nameservers:   appendUnique(s.Nameservers, other.Nameservers),
+		SearchDomains: appendUnique(s.SearchDomains, other.SearchDomains),
+		NTPServers:    appendUnique(s.NTPServers, other.NTPServers),
+	}
+}
+
+// addToMetadata sets the set fields of the networkServices in the metadata.
+func (s networkServices) addToMetadata(metadata map[string]any) {
+	for key, values := range map[string][]string{
+		metadataKeyDNSNameservers:   s.Nameservers,
+		metadataKeyDNSSearchDomains: s.SearchDomains,
+		metadataKeyNTPServers:       s.NTPServers,
+	} {
+		if len(values) > 0 {
+			metadata[key] = values
+		}
+	}
+}
+
+// networkServicesFromMetadata returns the networkServices set in the metadata of a network.
+func networkServicesFromMetadata(metadata map[string]any) networkServices {
+	get := func(key string) []string {
+		switch values := metadata[key].(type) {
+		case []string:
+			return values
+		case []any:
+			var strs []string
+			for _, value := range values {
+				if str, ok := value.(string); ok {
+					strs = append(strs, str)
+				}
+			}
+			return strs
+		}
+		return nil
+	}
+	return networkServices{
+		Nameservers:   get(metadataKeyDNSNameservers),
+		SearchDomains: get(metadataKeyDNSSearchDomains),
+		NTPServers:    get(metadataKeyNTPServers),
+	}
+}
+
+// clusterNetworkServices returns the DNS and NTP servers configured for all machines of the cluster.
+func clusterNetworkServices(spec *infrav1alpha1.IroncoreMetalClusterSpec) networkServices {
+	var services networkServices
+	if spec.DNS != nil {
+		services.Nameservers = spec.DNS.Nameservers
+		services.SearchDomains = spec.DNS.SearchDomains
+	}
+	if spec.NTP != nil {
+		services.NTPServers = spec.NTP.Servers
+	}
+	return services
+}
+
+// addNetworkServicesMetadata adds the DNS and NTP servers to the metadata. The networks of the IPAMConfig get the
+// servers of their IPAM pools or the ones of the cluster, the top level of the metadata gets all of them. It returns
+// the servers added to the top level.
+func addNetworkServicesMetadata(metadata map[string]any, ipAddressesMetadata map[string]any, spec *infrav1alpha1.IroncoreMetalClusterSpec) networkServices {
+	defaults := clusterNetworkServices(spec)
+	services := defaults
+	for _, key := range slices.Sorted(maps.Keys(ipAddressesMetadata)) {
+		network, ok := ipAddressesMetadata[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		overrides := networkServicesFromMetadata(network)
+		services = services.union(overrides)
+		overrides.withDefaults(defaults).addToMetadata(network)
+	}
+	services.addToMetadata(metadata)
+	return services
+}
+
+// networkServicesFiles renders the systemd-resolved and systemd-timesyncd drop-ins if they are enabled in the
+// IroncoreMetalClusterSpec.
+func networkServicesFiles(spec *infrav1alpha1.IroncoreMetalClusterSpec, services networkServices) ([]staticFile, error) {
+	var templates []staticFileTemplate
+	if spec.DNS != nil && spec.DNS.ConfigureResolved && (len(services.Nameservers) > 0 || len(services.SearchDomains) > 0) {
+		templates = append(templates, staticFileTemplate{path: resolvedDropInFile, mode: fileMode, template: resolvedDropInTemplate})
+	}
+	if spec.NTP != nil && spec.NTP.ConfigureTimesyncd && len(services.NTPServers) > 0 {
+		templates = append(templates, staticFileTemplate{path: timesyncdDropInFile, mode: fileMode, template: timesyncdDropInTemplate})
+	}
+	return renderStaticFiles(services, templates)
+}
+
+// ipAddressNetworkServices returns the DNS and NTP servers the IPAddress or its IPAM pool are annotated with. The
+// annotations of the IPAddress take precedence over the ones of the pool. Pools which are unknown or can't be read
+// are skipped.
+func (r *IroncoreMetalMachineReconciler) ipAddressNetworkServices(ctx context.Context, log *logr.Logger, ipAddr *capiv1beta2.IPAddress) (networkServices, error) {
+	services := networkServicesFromAnnotations(ipAddr.Annotations)
+	poolRef := ipAddr.Spec.PoolRef
+	if poolRef.Name == "" {
+		return services, nil
+	}
+
+	mapping, err := r.RESTMapper().RESTMapping(schema.GroupKind{Group: poolRef.APIGroup, Kind: poolRef.Kind})
+	if meta.IsNoMatchError(err) {
+		log.V(3).Info("IPAM pool kind is unknown", "apiGroup", poolRef.APIGroup, "kind", poolRef.Kind)
+		return services, nil
+	}
+	if err != nil {
+		return networkServices{}, fmt.Errorf("failed to get REST mapping of %s: %w", poolRef.Kind, err)
+	}
+
+	// unstructured objects are read from the API server, the pools are not cached
+	pool := &unstructured.Unstructured{}
+	pool.SetGroupVersionKind(mapping.GroupVersionKind)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: ipAddr.Namespace, Name: poolRef.Name}, pool); err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+			log.V(3).Info("IPAM pool can't be read", "kind", poolRef.Kind, "name", poolRef.Name, "error", err.Error())
+			return services, nil
+		}
+		return networkServices{}, fmt.Errorf("failed to get IPAM pool %s %s: %w", poolRef.Kind, poolRef.Name, err)
+	}
+
+	return services.withDefaults(networkServicesFromAnnotations(pool.GetAnnotations())), nil
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+)
+
+var _ = Describe("Network services", func() {
+	clusterSpec := &infrav1alpha1.IroncoreMetalClusterSpec{
+		DNS: &infrav1alpha1.DNSConfig{
+			Nameservers:       []string{"10.0.0.53"},
+			SearchDomains:     []string{"example.com"},
+			ConfigureResolved: true,
+		},
+		NTP: &infrav1alpha1.NTPConfig{
+			Servers: []string{"ntp.example.com"},
+		},
+	}
+
+	It("should read the overrides from comma-separated annotations", func() {
+		Expect(networkServicesFromAnnotations(map[string]string{
+			AnnotationKeyDNSNameservers: "10.1.0.53, 10.1.0.54,",
+			AnnotationKeyNTPServers:     "10.1.0.123",
+		})).To(Equal(networkServices{
+			Nameservers: []string{"10.1.0.53", "10.1.0.54"},
+			NTPServers:  []string{"10.1.0.123"},
+		}))
+	})
+
+	It("should add the servers of the cluster and the overrides of the networks to the metadata", func() {
+		ipAddressesMetadata := map[string]any{
+			"storage": map[string]any{"ip": "10.1.0.2"},
+			"public": map[string]any{
+				"ip":                      "10.2.0.2",
+				metadataKeyDNSNameservers: []string{"10.2.0.53"},
+			},
+		}
+		metadata := map[string]any{}
+		for key, value := range ipAddressesMetadata {
+			metadata[key] = value
+		}
+
+		services := addNetworkServicesMetadata(metadata, ipAddressesMetadata, clusterSpec)
+		Expect(services).To(Equal(networkServices{
+			Nameservers:   []string{"10.0.0.53", "10.2.0.53"},
+			SearchDomains: []string{"example.com"},
+			NTPServers:    []string{"ntp.example.com"},
+		}))
+		Expect(metadata).To(Equal(map[string]any{
+			"storage": map[string]any{
+				"ip":                        "10.1.0.2",
+				metadataKeyDNSNameservers:   []string{"10.0.0.53"},
+				metadataKeyDNSSearchDomains: []string{"example.com"},
+				metadataKeyNTPServers:       []string{"ntp.example.com"},
+			},
+			"public": map[string]any{
+				"ip":                        "10.2.0.2",
+				metadataKeyDNSNameservers:   []string{"10.2.0.53"},
+				metadataKeyDNSSearchDomains: []string{"example.com"},
+				metadataKeyNTPServers:       []string{"ntp.example.com"},
+			},
+			metadataKeyDNSNameservers:   []string{"10.0.0.53", "10.2.0.53"},
+			metadataKeyDNSSearchDomains: []string{"example.com"},
+			metadataKeyNTPServers:       []string{"ntp.example.com"},
+		}))
+	})
+
+	It("should render the drop-ins which are enabled", func() {
+		files, err := networkServicesFiles(clusterSpec, networkServices{
+			Nameservers:   []string{"10.0.0.53", "10.2.0.53"},
+			SearchDomains: []string{"example.com"},
+			NTPServers:    []string{"ntp.example.com"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(ConsistOf(staticFile{
+			Path: resolvedDropInFile,
+			Mode: fileMode,
+			Data: []byte("[Resolve]\nDNS=10.0.0.53 10.2.0.53\nDomains=example.com\n"),
+		}))
+	})
+})
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/scope"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// requestedServerPower returns the power state of the Server requested by the PowerAnnotation of the
+// IroncoreMetalMachine.
+func requestedServerPower(ironcoremetalmachine *infrav1alpha1.IroncoreMetalMachine) metalv1alpha1.Power {
+	if metalv1alpha1.Power(ironcoremetalmachine.Annotations[infrav1alpha1.PowerAnnotation]) == metalv1alpha1.PowerOff {
+		return metalv1alpha1.PowerOff
+	}
+	return metalv1alpha1.PowerOn
+}
+
+// reconcileServerOperation passes the operation requested by the ServerOperationAnnotation of the
+// IroncoreMetalMachine to the bound Server and removes the annotation. The operation is kept pending while the Server
+// is not powered on or has not finished a previous operation yet.
+func (r *IroncoreMetalMachineReconciler) reconcileServerOperation(ctx context.Context, machineScope *scope.MachineScope, server *metalv1alpha1.Server) error {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+	operation, ok := ironcoremetalmachine.Annotations[infrav1alpha1.ServerOperationAnnotation]
+	if !ok || server == nil {
+		return nil
+	}
+	if !slices.Contains(infrav1alpha1.ServerOperations, operation) {
+		machineScope.Info("Dropping unsupported Server operation", "Operation", operation)
+		delete(ironcoremetalmachine.Annotations, infrav1alpha1.ServerOperationAnnotation)
+		return nil
+	}
+	if server.Status.PowerState != metalv1alpha1.ServerOnPowerState {
+		machineScope.Info("Waiting for the Server to be powered on to pass the operation", "Operation", operation)
+		return nil
+	}
+	if pending, ok := server.Annotations[metalv1alpha1.OperationAnnotation]; ok {
+		machineScope.Info("Waiting for the Server to finish the previous operation", "Operation", operation, "PendingOperation", pending)
+		return nil
+	}
+
+	serverBase := server.DeepCopy()
+	metav1.SetMetaDataAnnotation(&server.ObjectMeta, metalv1alpha1.OperationAnnotation, operation)
+	if err := machineScope.MetalClient.Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch the operation annotation of Server %s: %w", server.Name, err)
+	}
+	machineScope.Info("Passed the operation to the Server", "Server", server.Name, "Operation", operation)
+	delete(ironcoremetalmachine.Annotations, infrav1alpha1.ServerOperationAnnotation)
+	return nil
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/scope"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clusterapiv1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LabelKeyServerAffinity is set on the Servers bound to IroncoreMetalMachines with ServerAffinity. Its value
+// identifies the MachineDeployment or control plane owning the Machine.
+const LabelKeyServerAffinity = "metal.ironcore.dev/server-affinity"
+
+// serverAffinityGroup returns the value of the LabelKeyServerAffinity of the Machine, which is shared by the Machines
+// replacing each other. It is empty if the Machine is not part of a MachineDeployment, a MachineSet or a control plane.
+func serverAffinityGroup(machine *clusterapiv1beta2.Machine) string {
+	for _, key := range []string{
+		clusterapiv1beta2.MachineDeploymentNameLabel,
+		clusterapiv1beta2.MachineControlPlaneNameLabel,
+		clusterapiv1beta2.MachineSetNameLabel,
+	} {
+		if owner := machine.Labels[key]; owner != "" {
+			return truncateWithHash(strings.Join([]string{machine.Namespace, machine.Spec.ClusterName, owner}, "."), validation.LabelValueMaxLength)
+		}
+	}
+	return ""
+}
+
+// serverAffinityRef returns the Server a new ServerClaim of an IroncoreMetalMachine with ServerAffinity is bound to.
+// Only Servers of the same affinity group which match the ServerSelector of the machine are considered. Available
+// Servers are preferred over Servers which are still released by their deleted IroncoreMetalMachine. It returns nil
+// if the ServerClaim exists already or no such Server is found.
+func (r *IroncoreMetalMachineReconciler) serverAffinityRef(ctx context.Context, machineScope *scope.MachineScope, serverSelector *metav1.LabelSelector) (*corev1.LocalObjectReference, error) {
+	ironcoremetalmachine := machineScope.IroncoreMetalMachine
+	group := serverAffinityGroup(machineScope.Machine)
+	if !ironcoremetalmachine.Spec.ServerAffinity || group == "" {
+		return nil, nil
+	}
+
+	if err := getMetalObject(ctx, machineScope, serverClaimKey(machineScope), &metalv1alpha1.ServerClaim{}); !apierrors.IsNotFound(err) {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	selector := labels.Everything()
+	if serverSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(serverSelector); err != nil {
+			return nil, fmt.Errorf("failed to convert the ServerSelector: %w", err)
+		}
+	}
+	requirement, err := labels.NewRequirement(LabelKeyServerAffinity, selection.Equals, []string{group})
+	if err != nil {
+		return nil, fmt.Errorf("failed to select the Servers of affinity group %s: %w", group, err)
+	}
+
+	servers := &metalv1alpha1.ServerList{}
+	if err := machineScope.MetalClient.List(ctx, servers, client.MatchingLabelsSelector{Selector: selector.Add(*requirement)}); err != nil {
+		return nil, fmt.Errorf("failed to list Servers: %w", err)
+	}
+	serverClaims := &metalv1alpha1.ServerClaimList{}
+	if err := machineScope.MetalClient.List(ctx, serverClaims, client.InNamespace(machineScope.MetalNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list ServerClaims: %w", err)
+	}
+	targeted := make(map[string]bool, len(serverClaims.Items))
+	for _, serverClaim := range serverClaims.Items {
+		if serverClaim.Spec.ServerRef != nil && serverClaim.DeletionTimestamp.IsZero() {
+			targeted[serverClaim.Spec.ServerRef.Name] = true
+		}
+	}
+
+	var available, releasing []string
+	for _, server := range servers.Items {
+		if targeted[server.Name] {
+			continue
+		}
+		claimRef := server.Spec.ServerClaimRef
+		if claimRef == nil {
+			if server.Status.State == metalv1alpha1.ServerStateAvailable {
+				available = append(available, server.Name)
+			}
+			continue
+		}
+		if machineScope.RemoteMetalCluster() {
+			// the ServerClaims in a remote cluster are not named after their IroncoreMetalMachine, the Server is
+			// released once its ServerClaim is deleted together with the IroncoreMetalMachine
+			if claimRef.Namespace == machineScope.MetalNamespace {
+				releasing = append(releasing, server.Name)
+			}
+			continue
+		}
+		previous := &infrav1alpha1.IroncoreMetalMachine{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: claimRef.Namespace, Name: claimRef.Name}, previous); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get IroncoreMetalMachine %s/%s: %w", claimRef.Namespace, claimRef.Name, err)
+			}
+			releasing = append(releasing, server.Name)
+			continue
+		}
+		if !previous.DeletionTimestamp.IsZero() {
+			releasing = append(releasing, server.Name)
+		}
+	}
+
+	for _, names := range [][]string{available, releasing} {
+		if len(names) > 0 {
+			machineScope.Info("Claiming the Server of a replaced machine", "Server", slices.Min(names))
+			return &corev1.LocalObjectReference{Name: slices.Min(names)}, nil
+		}
+	}
+	return nil, nil
+}
+
+// ensureServerAffinityLabel labels the Server bound to an IroncoreMetalMachine with ServerAffinity with its affinity
+// group, so the Server is re-claimed by the replacement of the IroncoreMetalMachine.
+func (r *IroncoreMetalMachineReconciler) ensureServerAffinityLabel(ctx context.Context, machineScope *scope.MachineScope, server *metalv1alpha1.Server) error {
+	group := serverAffinityGroup(machineScope.Machine)
+	if !machineScope.IroncoreMetalMachine.Spec.ServerAffinity || group == "" || server == nil || server.Labels[LabelKeyServerAffinity] == group {
+		return nil
+	}
+
+	serverBase := server.DeepCopy()
+	metav1.SetMetaDataLabel(&server.ObjectMeta, LabelKeyServerAffinity, group)
+	if err := machineScope.MetalClient.Patch(ctx, server, client.MergeFrom(serverBase)); err != nil {
+		return fmt.Errorf("failed to patch the affinity label of Server %s: %w", server.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ironcore-dev/cluster-api-provider-ironcore-metal/internal/scope"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+)
+
+// Variables which can be used in the bootstrap data as $${NAME}, either plain or URL-encoded.
+const (
+	variableHostname         = "METAL_HOSTNAME"
+	variableMachineName      = "METAL_MACHINE_NAME"
+	variableMachineNamespace = "METAL_MACHINE_NAMESPACE"
+	variableClusterName      = "METAL_CLUSTER_NAME"
+	variableProviderID       = "METAL_PROVIDER_ID"
+	variableServerName       = "METAL_SERVER_NAME"
+	// variableServerLabelPrefix is followed by the key of a label of the Server.
+	variableServerLabelPrefix = "METAL_SERVER_LABEL_"
+	// variableIPPrefix, variablePrefixPrefix and variableGatewayPrefix are followed by the MetadataKey of an IPAMConfig.
+	variableIPPrefix      = "METAL_IP_"
+	variablePrefixPrefix  = "METAL_PREFIX_"
+	variableGatewayPrefix = "METAL_GATEWAY_"
+	// variableMetadataPrefix is followed by a key of the Metadata of the IroncoreMetalMachine.
+	variableMetadataPrefix = "METAL_METADATA_"
+)
+
+var (
+	plainVariableRegexp      = regexp.MustCompile(`\$\$\{([^{}$]+)\}`)
+	urlEncodedVariableRegexp = regexp.MustCompile(`%24%24%7B(.+?)%7D`)
+)
+
+// unresolvedVariablesError is returned if the bootstrap data references variables without a value.
+type unresolvedVariablesError struct {
+	names []string
+}
+
+func (e *unresolvedVariablesError) Error() string {
+	return fmt.Sprintf("unresolved variables in bootstrap data: %s", strings.Join(e.names, ", "))
+}
+
+// onlyServerVariables returns true if all unresolved variables refer to the Server.
+func (e *unresolvedVariablesError) onlyServerVariables() bool {
+	for _, name := range e.names {
+		if name != variableServerName && !strings.HasPrefix(name, variableServerLabelPrefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// bootstrapDataVariables returns the values of the variables of the bootstrap data. The Server variables are only
+// set if server is not nil.
+func bootstrapDataVariables(machineScope *scope.MachineScope, ipAddressesMetadata map[string]any, server *metalv1alpha1.Server) (map[string]string, error) {
+	metalMachine := machineScope.IroncoreMetalMachine
+	variables := map[string]string{
+		variableHostname:         metalMachine.Name,
+		variableMachineName:      metalMachine.Name,
+		variableMachineNamespace: metalMachine.Namespace,
+		variableClusterName:      machineScope.Cluster.Name,
+		variableProviderID:       machineProviderID(machineScope),
+	}
+
+	if server != nil {
+		variables[variableServerName] = server.Name
+		for key, value := range server.Labels {
+			variables[variableServerLabelPrefix+key] = value
+		}
+	}
+
+	for key, value := range ipAddressesMetadata {
+		ipAddress, _ := value.(map[string]any)
+		variables[variableIPPrefix+key] = fmt.Sprint(ipAddress["ip"])
+		if prefix, ok := ipAddress["prefix"].(*int32); ok && prefix != nil {
+			variables[variablePrefixPrefix+key] = fmt.Sprint(*prefix)
+		}
+		if gateway, ok := ipAddress["gateway"].(string); ok && gateway != "" {
+			variables[variableGatewayPrefix+key] = gateway
+		}
+	}
+
+	if metalMachine.Spec.Metadata != nil {
+		metadata := make(map[string]any)
+		if err := json.Unmarshal(metalMachine.Spec.Metadata.Raw, &metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+		for key, value := range metadata {
+			if s, ok := value.(string); ok {
+				variables[variableMetadataPrefix+key] = s
+				continue
+			}
+			raw, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal metadata %s: %w", key, err)
+			}
+			variables[variableMetadataPrefix+key] = string(raw)
+		}
+	}
+
+	return variables, nil
+}
+
+// renderBootstrapData replaces the plain and URL-encoded variables of the bootstrap data. Values of plain variables
+// are passed through escape. An unresolvedVariablesError is returned if a variable has no value.
+func renderBootstrapData(data []byte, variables map[string]string, escape func(string) string) ([]byte, error) {
+	var unresolved []string
+	lookup := func(name string) (string, bool) {
+		value, ok := variables[name]
+		if !ok && !slices.Contains(unresolved, name) {
+			unresolved = append(unresolved, name)
+		}
+		return value, ok
+	}
+
+	rendered := urlEncodedVariableRegexp.ReplaceAllFunc(data, func(match []byte) []byte {
+		name, err := url.PathUnescape(string(urlEncodedVariableRegexp.FindSubmatch(match)[1]))
+		if err != nil {
+			return match
+		}
+		if value, ok := lookup(name); ok {
+			return []byte(url.PathEscape(value))
+		}
+		return match
+	})
+	rendered = plainVariableRegexp.ReplaceAllFunc(rendered, func(match []byte) []byte {
+		if value, ok := lookup(string(plainVariableRegexp.FindSubmatch(match)[1])); ok {
+			return []byte(escape(value))
+		}
+		return match
+	})
+
+	if len(unresolved) > 0 {
+		slices.Sort(unresolved)
+		return nil, &unresolvedVariablesError{names: unresolved}
+	}
+	return rendered, nil
+}
+
+// escapeJSONString escapes a value to be placed inside a JSON string.
+func escapeJSONString(value string) string {
+	raw, _ := json.Marshal(value)
+	return string(raw[1 : len(raw)-1])
+}
+
+// noEscape returns the value as is.
+func noEscape(value string) string {
+	return value
+}
@@ -100,6 +100,11 @@ func (s *ClusterScope) PatchObject() error {
 	if err := conditions.SetSummaryCondition(s.IroncoreMetalCluster, s.IroncoreMetalCluster, clusterv1.ReadyCondition,
 		conditions.ForConditionTypes{
 			infrav1.IroncoreMetalClusterReady,
+			infrav1.ControlPlaneEndpointReadyCondition,
+		},
+		// ControlPlaneEndpointReady is only set when the endpoint is allocated from an IPAM pool.
+		conditions.IgnoreTypesIfMissing{
+			infrav1.ControlPlaneEndpointReadyCondition,
 		},
 	); err != nil {
 		return fmt.Errorf("unable to set summary condition: %w", err)
@@ -5,12 +5,15 @@ package scope
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	infrav1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
 	"github.com/ironcore-dev/metal-operator/api/v1alpha1"
 	"github.com/pkg/errors"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -24,6 +27,11 @@ type MachineScopeParams struct {
 	Machine              *clusterv1.Machine
 	IroncoreMetalCluster *infrav1.IroncoreMetalCluster
 	IroncoreMetalMachine *infrav1.IroncoreMetalMachine
+	// MetalClient is the client of the cluster running the metal-operator. Defaults to Client.
+	MetalClient client.Client
+	// MetalNamespace is the namespace of the ServerClaims in the cluster of the MetalClient. Defaults to the
+	// namespace of the IroncoreMetalMachine.
+	MetalNamespace string
 }
 
 // MachineScope defines the basic context for an actuator to operate upon.
@@ -36,6 +44,8 @@ type MachineScope struct {
 	IroncoreMetalCluster *infrav1.IroncoreMetalCluster
 	IroncoreMetalMachine *infrav1.IroncoreMetalMachine
 	ServerClaim          *v1alpha1.ServerClaim
+	MetalClient          client.Client
+	MetalNamespace       string
 }
 
 // NewMachineScope creates a new Scope from the supplied parameters.
@@ -60,6 +70,12 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 		logger := log.FromContext(context.Background())
 		params.Logger = &logger
 	}
+	if params.MetalClient == nil {
+		params.MetalClient = params.Client
+	}
+	if params.MetalNamespace == "" {
+		params.MetalNamespace = params.IroncoreMetalMachine.Namespace
+	}
 
 	machineScope := &MachineScope{
 		Logger:               params.Logger,
@@ -68,6 +84,8 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 		Machine:              params.Machine,
 		IroncoreMetalCluster: params.IroncoreMetalCluster,
 		IroncoreMetalMachine: params.IroncoreMetalMachine,
+		MetalClient:          params.MetalClient,
+		MetalNamespace:       params.MetalNamespace,
 	}
 
 	helper, err := patch.NewHelper(params.IroncoreMetalMachine, params.Client)
@@ -83,7 +101,17 @@ func NewMachineScope(params MachineScopeParams) (*MachineScope, error) {
 // PatchObject persists the Machine configuration and status.
 func (m *MachineScope) PatchObject() error {
 	// always update the readyCondition.
-	// TBD readyCondition
+	if err := conditions.SetSummaryCondition(m.IroncoreMetalMachine, m.IroncoreMetalMachine, clusterv1.ReadyCondition,
+		conditions.ForConditionTypes{
+			infrav1.BootstrapDataReadyCondition,
+			infrav1.IPAddressesAllocatedCondition,
+			infrav1.IgnitionReadyCondition,
+			infrav1.ServerClaimBoundCondition,
+			infrav1.ServerReadyCondition,
+		},
+	); err != nil {
+		return fmt.Errorf("unable to set summary condition: %w", err)
+	}
 
 	return m.patchHelper.Patch(context.TODO(), m.IroncoreMetalMachine)
 }
@@ -92,3 +120,13 @@ func (m *MachineScope) PatchObject() error {
 func (m *MachineScope) Close() error {
 	return m.PatchObject()
 }
+
+// IsControlPlane returns true if the Machine is a control plane machine.
+func (m *MachineScope) IsControlPlane() bool {
+	return util.IsControlPlaneMachine(m.Machine)
+}
+
+// RemoteMetalCluster returns true if the ServerClaims are managed in a remote cluster running the metal-operator.
+func (m *MachineScope) RemoteMetalCluster() bool {
+	return m.IroncoreMetalCluster.Spec.MetalKubeconfigSecretRef != nil
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupIroncoreMetalClusterWebhookWithManager registers the webhooks for IroncoreMetalCluster in the manager.
+func SetupIroncoreMetalClusterWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &infrav1alpha1.IroncoreMetalCluster{}).
+		WithValidator(&IroncoreMetalClusterCustomValidator{}).
+		WithDefaulter(&IroncoreMetalClusterCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1alpha1-ironcoremetalcluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalclusters,verbs=create;update,versions=v1alpha1,name=mironcoremetalcluster-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// IroncoreMetalClusterCustomDefaulter sets the defaults of an IroncoreMetalCluster.
+type IroncoreMetalClusterCustomDefaulter struct{}
+
+var _ admission.Defaulter[*infrav1alpha1.IroncoreMetalCluster] = &IroncoreMetalClusterCustomDefaulter{}
+
+// Default implements admission.Defaulter.
+func (d *IroncoreMetalClusterCustomDefaulter) Default(_ context.Context, metalCluster *infrav1alpha1.IroncoreMetalCluster) error {
+	defaultIroncoreMetalClusterSpec(&metalCluster.Spec)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-ironcoremetalcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalclusters,verbs=create;update,versions=v1alpha1,name=vironcoremetalcluster-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// IroncoreMetalClusterCustomValidator validates an IroncoreMetalCluster.
+type IroncoreMetalClusterCustomValidator struct{}
+
+var _ admission.Validator[*infrav1alpha1.IroncoreMetalCluster] = &IroncoreMetalClusterCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *IroncoreMetalClusterCustomValidator) ValidateCreate(_ context.Context, metalCluster *infrav1alpha1.IroncoreMetalCluster) (admission.Warnings, error) {
+	allErrs := validateIroncoreMetalClusterSpec(&metalCluster.Spec, field.NewPath("spec"))
+	return nil, ironcoreMetalClusterInvalid(metalCluster, allErrs)
+}
+
+// ValidateUpdate implements admission.Validator. Errors which the old IroncoreMetalCluster has already had are
+// ratcheted, so IroncoreMetalClusters created before a validation was added can still be updated. An
+// IroncoreMetalCluster which is being deleted is not validated, so its finalizer can always be removed.
+func (v *IroncoreMetalClusterCustomValidator) ValidateUpdate(_ context.Context, oldMetalCluster, newMetalCluster *infrav1alpha1.IroncoreMetalCluster) (admission.Warnings, error) {
+	if !newMetalCluster.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	specPath := field.NewPath("spec")
+	allErrs := ratchetErrors(
+		validateIroncoreMetalClusterSpec(&oldMetalCluster.Spec, specPath),
+		validateIroncoreMetalClusterSpec(&newMetalCluster.Spec, specPath),
+	)
+	allErrs = append(allErrs, validateIroncoreMetalClusterSpecUpdate(&oldMetalCluster.Spec, &newMetalCluster.Spec, specPath)...)
+	return nil, ironcoreMetalClusterInvalid(newMetalCluster, allErrs)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *IroncoreMetalClusterCustomValidator) ValidateDelete(_ context.Context, _ *infrav1alpha1.IroncoreMetalCluster) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func ironcoreMetalClusterInvalid(metalCluster *infrav1alpha1.IroncoreMetalCluster, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(infrav1alpha1.GroupVersion.WithKind("IroncoreMetalCluster").GroupKind(), metalCluster.Name, allErrs)
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+var _ = Describe("IroncoreMetalCluster Webhook", func() {
+	var (
+		metalCluster *infrav1alpha1.IroncoreMetalCluster
+		validator    *IroncoreMetalClusterCustomValidator
+		defaulter    *IroncoreMetalClusterCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		metalCluster = &infrav1alpha1.IroncoreMetalCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-cluster",
+			},
+			Spec: infrav1alpha1.IroncoreMetalClusterSpec{
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "10.0.0.1"},
+			},
+		}
+		validator = &IroncoreMetalClusterCustomValidator{}
+		defaulter = &IroncoreMetalClusterCustomDefaulter{}
+	})
+
+	It("should default the port of the control plane endpoint", func(ctx SpecContext) {
+		Expect(defaulter.Default(ctx, metalCluster)).To(Succeed())
+		Expect(metalCluster.Spec.ControlPlaneEndpoint.Port).To(Equal(int32(6443)))
+	})
+
+	It("should default the port of the control plane endpoint next to the kube-apiserver for keepalived", func(ctx SpecContext) {
+		metalCluster.Spec.ControlPlaneLoadBalancer = &infrav1alpha1.ControlPlaneLoadBalancer{
+			Type:          infrav1alpha1.ControlPlaneLoadBalancerKeepalived,
+			Interface:     "eth0",
+			APIServerPort: 6443,
+		}
+		metalCluster.Spec.ControlPlaneEndpointIPAM = &infrav1alpha1.ControlPlaneEndpointIPAMConfig{
+			IPAMRef: &infrav1alpha1.IPAMObjectReference{Name: "pool", Kind: "GlobalInClusterIPPool"},
+		}
+		Expect(defaulter.Default(ctx, metalCluster)).To(Succeed())
+		Expect(metalCluster.Spec.ControlPlaneEndpoint.Port).To(Equal(int32(8443)))
+		Expect(metalCluster.Spec.ControlPlaneEndpointIPAM.Port).To(Equal(int32(8443)))
+
+		_, err := validator.ValidateCreate(ctx, metalCluster)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject a control plane endpoint IPAM config without an IPAM reference", func(ctx SpecContext) {
+		metalCluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{}
+		metalCluster.Spec.ControlPlaneEndpointIPAM = &infrav1alpha1.ControlPlaneEndpointIPAMConfig{}
+
+		_, err := validator.ValidateCreate(ctx, metalCluster)
+		Expect(err).To(MatchError(ContainSubstring("spec.controlPlaneEndpointIPAM.ipamRef")))
+	})
+
+	It("should reject a control plane load balancer for a DNS name", func(ctx SpecContext) {
+		metalCluster.Spec.ControlPlaneEndpoint.Host = "api.example.com"
+		metalCluster.Spec.ControlPlaneLoadBalancer = &infrav1alpha1.ControlPlaneLoadBalancer{
+			Type:      infrav1alpha1.ControlPlaneLoadBalancerKubeVIP,
+			Interface: "eth0",
+		}
+
+		_, err := validator.ValidateCreate(ctx, metalCluster)
+		Expect(err).To(MatchError(ContainSubstring("spec.controlPlaneEndpoint.host")))
+	})
+
+	It("should reject a keepalived load balancer listening on the port of the API server", func(ctx SpecContext) {
+		metalCluster.Spec.ControlPlaneEndpoint.Port = 6443
+		metalCluster.Spec.ControlPlaneLoadBalancer = &infrav1alpha1.ControlPlaneLoadBalancer{
+			Type:          infrav1alpha1.ControlPlaneLoadBalancerKeepalived,
+			Interface:     "eth0",
+			APIServerPort: 6443,
+		}
+
+		_, err := validator.ValidateCreate(ctx, metalCluster)
+		Expect(err).To(MatchError(ContainSubstring("spec.controlPlaneLoadBalancer.apiServerPort")))
+
+		metalCluster.Spec.ControlPlaneLoadBalancer.APIServerPort = 6444
+		_, err = validator.ValidateCreate(ctx, metalCluster)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should validate the DNS and NTP servers", func(ctx SpecContext) {
+		metalCluster.Spec.DNS = &infrav1alpha1.DNSConfig{
+			Nameservers:   []string{"10.0.0.53", "dns.example.com"},
+			SearchDomains: []string{"example.com", "Example_com"},
+		}
+		metalCluster.Spec.NTP = &infrav1alpha1.NTPConfig{
+			Servers: []string{"10.0.0.123", "ntp.example.com", "ntp server"},
+		}
+
+		_, err := validator.ValidateCreate(ctx, metalCluster)
+		Expect(err).To(MatchError(ContainSubstring("spec.dns.nameservers[1]")))
+		Expect(err).To(MatchError(ContainSubstring("spec.dns.searchDomains[1]")))
+		Expect(err).To(MatchError(ContainSubstring("spec.ntp.servers[2]")))
+		Expect(err).NotTo(MatchError(ContainSubstring("spec.ntp.servers[1]")))
+	})
+
+	It("should allow to set the control plane endpoint once", func(ctx SpecContext) {
+		oldMetalCluster := metalCluster.DeepCopy()
+		oldMetalCluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{}
+
+		_, err := validator.ValidateUpdate(ctx, oldMetalCluster, metalCluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		newMetalCluster := metalCluster.DeepCopy()
+		newMetalCluster.Spec.ControlPlaneEndpoint.Host = "10.0.0.2"
+		_, err = validator.ValidateUpdate(ctx, metalCluster, newMetalCluster)
+		Expect(err).To(MatchError(ContainSubstring("controlPlaneEndpoint is immutable once set")))
+	})
+
+	It("should validate the target namespace of the metal kubeconfig secret reference", func(ctx SpecContext) {
+		metalCluster.Spec.MetalKubeconfigSecretRef = &infrav1alpha1.MetalKubeconfigSecretReference{
+			Name:            "metal-kubeconfig",
+			TargetNamespace: "Metal_Namespace",
+		}
+		_, err := validator.ValidateCreate(ctx, metalCluster)
+		Expect(err).To(MatchError(ContainSubstring("spec.metalKubeconfigSecretRef.targetNamespace")))
+
+		metalCluster.Spec.MetalKubeconfigSecretRef.TargetNamespace = "metal"
+		_, err = validator.ValidateCreate(ctx, metalCluster)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject adding the metal kubeconfig secret reference", func(ctx SpecContext) {
+		newMetalCluster := metalCluster.DeepCopy()
+		newMetalCluster.Spec.MetalKubeconfigSecretRef = &infrav1alpha1.MetalKubeconfigSecretReference{Name: "metal-kubeconfig"}
+
+		_, err := validator.ValidateUpdate(ctx, metalCluster, newMetalCluster)
+		Expect(err).To(MatchError(ContainSubstring("metalKubeconfigSecretRef is immutable")))
+	})
+
+	It("should reject changing or removing the metal kubeconfig secret reference", func(ctx SpecContext) {
+		metalCluster.Spec.MetalKubeconfigSecretRef = &infrav1alpha1.MetalKubeconfigSecretReference{Name: "metal-kubeconfig"}
+
+		newMetalCluster := metalCluster.DeepCopy()
+		newMetalCluster.Spec.MetalKubeconfigSecretRef.Name = "other-kubeconfig"
+		_, err := validator.ValidateUpdate(ctx, metalCluster, newMetalCluster)
+		Expect(err).To(MatchError(ContainSubstring("metalKubeconfigSecretRef is immutable")))
+
+		newMetalCluster.Spec.MetalKubeconfigSecretRef = nil
+		_, err = validator.ValidateUpdate(ctx, metalCluster, newMetalCluster)
+		Expect(err).To(MatchError(ContainSubstring("metalKubeconfigSecretRef is immutable")))
+	})
+
+	It("should allow to update a cluster which was created before a validation was added", func(ctx SpecContext) {
+		metalCluster.Spec.DNS = &infrav1alpha1.DNSConfig{SearchDomains: []string{"Example_com"}}
+
+		newMetalCluster := metalCluster.DeepCopy()
+		newMetalCluster.Finalizers = []string{infrav1alpha1.ClusterFinalizer}
+		_, err := validator.ValidateUpdate(ctx, metalCluster, newMetalCluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		newMetalCluster.Spec.DNS.SearchDomains = []string{"Other_com"}
+		_, err = validator.ValidateUpdate(ctx, metalCluster, newMetalCluster)
+		Expect(err).To(MatchError(ContainSubstring("spec.dns.searchDomains[0]")))
+	})
+
+	It("should not validate a cluster which is being deleted", func(ctx SpecContext) {
+		metalCluster.Spec.DNS = &infrav1alpha1.DNSConfig{SearchDomains: []string{"Example_com"}}
+		metalCluster.Finalizers = []string{infrav1alpha1.ClusterFinalizer}
+		metalCluster.DeletionTimestamp = ptr.To(metav1.Now())
+
+		newMetalCluster := metalCluster.DeepCopy()
+		newMetalCluster.Finalizers = nil
+		_, err := validator.ValidateUpdate(ctx, metalCluster, newMetalCluster)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupIroncoreMetalClusterTemplateWebhookWithManager registers the webhooks for IroncoreMetalClusterTemplate in the
+// manager.
+func SetupIroncoreMetalClusterTemplateWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &infrav1alpha1.IroncoreMetalClusterTemplate{}).
+		WithValidator(&IroncoreMetalClusterTemplateCustomValidator{}).
+		WithDefaulter(&IroncoreMetalClusterTemplateCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1alpha1-ironcoremetalclustertemplate,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalclustertemplates,verbs=create;update,versions=v1alpha1,name=mironcoremetalclustertemplate-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// IroncoreMetalClusterTemplateCustomDefaulter sets the defaults of an IroncoreMetalClusterTemplate.
+type IroncoreMetalClusterTemplateCustomDefaulter struct{}
+
+var _ admission.Defaulter[*infrav1alpha1.IroncoreMetalClusterTemplate] = &IroncoreMetalClusterTemplateCustomDefaulter{}
+
+// Default implements admission.Defaulter.
+func (d *IroncoreMetalClusterTemplateCustomDefaulter) Default(_ context.Context, template *infrav1alpha1.IroncoreMetalClusterTemplate) error {
+	defaultIroncoreMetalClusterSpec(&template.Spec.Template.Spec)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-ironcoremetalclustertemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalclustertemplates,verbs=create;update,versions=v1alpha1,name=vironcoremetalclustertemplate-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// IroncoreMetalClusterTemplateCustomValidator validates an IroncoreMetalClusterTemplate. The spec of a template is
+// immutable, changes are rolled out by creating a new template.
+type IroncoreMetalClusterTemplateCustomValidator struct{}
+
+var _ admission.Validator[*infrav1alpha1.IroncoreMetalClusterTemplate] = &IroncoreMetalClusterTemplateCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *IroncoreMetalClusterTemplateCustomValidator) ValidateCreate(_ context.Context, template *infrav1alpha1.IroncoreMetalClusterTemplate) (admission.Warnings, error) {
+	allErrs := validateIroncoreMetalClusterSpec(&template.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+	return nil, ironcoreMetalClusterTemplateInvalid(template, allErrs)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *IroncoreMetalClusterTemplateCustomValidator) ValidateUpdate(_ context.Context, oldTemplate, newTemplate *infrav1alpha1.IroncoreMetalClusterTemplate) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	if !equality.Semantic.DeepEqual(oldTemplate.Spec, newTemplate.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "IroncoreMetalClusterTemplate spec is immutable"))
+	}
+	return nil, ironcoreMetalClusterTemplateInvalid(newTemplate, allErrs)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *IroncoreMetalClusterTemplateCustomValidator) ValidateDelete(_ context.Context, _ *infrav1alpha1.IroncoreMetalClusterTemplate) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func ironcoreMetalClusterTemplateInvalid(template *infrav1alpha1.IroncoreMetalClusterTemplate, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(infrav1alpha1.GroupVersion.WithKind("IroncoreMetalClusterTemplate").GroupKind(), template.Name, allErrs)
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("IroncoreMetalClusterTemplate Webhook", func() {
+	var (
+		template  *infrav1alpha1.IroncoreMetalClusterTemplate
+		validator *IroncoreMetalClusterTemplateCustomValidator
+	)
+
+	BeforeEach(func() {
+		template = &infrav1alpha1.IroncoreMetalClusterTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-template",
+			},
+		}
+		validator = &IroncoreMetalClusterTemplateCustomValidator{}
+	})
+
+	It("should validate the spec of the template", func(ctx SpecContext) {
+		template.Spec.Template.Spec.ControlPlaneEndpointIPAM = &infrav1alpha1.ControlPlaneEndpointIPAMConfig{}
+
+		_, err := validator.ValidateCreate(ctx, template)
+		Expect(err).To(MatchError(ContainSubstring("spec.template.spec.controlPlaneEndpointIPAM.ipamRef")))
+	})
+
+	It("should reject changes of the spec", func(ctx SpecContext) {
+		newTemplate := template.DeepCopy()
+		newTemplate.Spec.Template.Spec.ControlPlaneEndpoint.Host = "10.0.0.1"
+
+		_, err := validator.ValidateUpdate(ctx, template, newTemplate)
+		Expect(err).To(MatchError(ContainSubstring("IroncoreMetalClusterTemplate spec is immutable")))
+	})
+})
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupIroncoreMetalMachineWebhookWithManager registers the webhooks for IroncoreMetalMachine in the manager.
+func SetupIroncoreMetalMachineWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &infrav1alpha1.IroncoreMetalMachine{}).
+		WithValidator(&IroncoreMetalMachineCustomValidator{}).
+		WithDefaulter(&IroncoreMetalMachineCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1alpha1-ironcoremetalmachine,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalmachines,verbs=create;update,versions=v1alpha1,name=mironcoremetalmachine-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// IroncoreMetalMachineCustomDefaulter sets the defaults of an IroncoreMetalMachine.
+type IroncoreMetalMachineCustomDefaulter struct{}
+
+var _ admission.Defaulter[*infrav1alpha1.IroncoreMetalMachine] = &IroncoreMetalMachineCustomDefaulter{}
+
+// Default implements admission.Defaulter.
+func (d *IroncoreMetalMachineCustomDefaulter) Default(_ context.Context, metalMachine *infrav1alpha1.IroncoreMetalMachine) error {
+	defaultIroncoreMetalMachineSpec(&metalMachine.Spec)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-ironcoremetalmachine,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalmachines,verbs=create;update,versions=v1alpha1,name=vironcoremetalmachine-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// IroncoreMetalMachineCustomValidator validates an IroncoreMetalMachine.
+type IroncoreMetalMachineCustomValidator struct{}
+
+var _ admission.Validator[*infrav1alpha1.IroncoreMetalMachine] = &IroncoreMetalMachineCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *IroncoreMetalMachineCustomValidator) ValidateCreate(_ context.Context, metalMachine *infrav1alpha1.IroncoreMetalMachine) (admission.Warnings, error) {
+	allErrs := validateIroncoreMetalMachineName(metalMachine.Name, field.NewPath("metadata", "name"))
+	allErrs = append(allErrs, validateIroncoreMetalMachineAnnotations(metalMachine.Annotations, field.NewPath("metadata", "annotations"))...)
+	allErrs = append(allErrs, validateIroncoreMetalMachineSpec(&metalMachine.Spec, field.NewPath("spec"))...)
+	return nil, ironcoreMetalMachineInvalid(metalMachine, allErrs)
+}
+
+// ValidateUpdate implements admission.Validator. Errors which the old IroncoreMetalMachine has already had are
+// ratcheted, so IroncoreMetalMachines created before a validation was added can still be updated. An
+// IroncoreMetalMachine which is being deleted is not validated, so its finalizer can always be removed.
+func (v *IroncoreMetalMachineCustomValidator) ValidateUpdate(_ context.Context, oldMetalMachine, newMetalMachine *infrav1alpha1.IroncoreMetalMachine) (admission.Warnings, error) {
+	if !newMetalMachine.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	annotationsPath, specPath := field.NewPath("metadata", "annotations"), field.NewPath("spec")
+	allErrs := ratchetErrors(
+		validateIroncoreMetalMachineAnnotations(oldMetalMachine.Annotations, annotationsPath),
+		validateIroncoreMetalMachineAnnotations(newMetalMachine.Annotations, annotationsPath),
+	)
+	allErrs = append(allErrs, ratchetErrors(
+		validateIroncoreMetalMachineSpec(&oldMetalMachine.Spec, specPath),
+		validateIroncoreMetalMachineSpec(&newMetalMachine.Spec, specPath),
+	)...)
+	allErrs = append(allErrs, validateIroncoreMetalMachineSpecUpdate(&oldMetalMachine.Spec, &newMetalMachine.Spec, specPath)...)
+	return nil, ironcoreMetalMachineInvalid(newMetalMachine, allErrs)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *IroncoreMetalMachineCustomValidator) ValidateDelete(_ context.Context, _ *infrav1alpha1.IroncoreMetalMachine) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func ironcoreMetalMachineInvalid(metalMachine *infrav1alpha1.IroncoreMetalMachine, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(infrav1alpha1.GroupVersion.WithKind("IroncoreMetalMachine").GroupKind(), metalMachine.Name, allErrs)
+}
@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("IroncoreMetalMachine Webhook", func() {
+	var (
+		metalMachine *infrav1alpha1.IroncoreMetalMachine
+		validator    *IroncoreMetalMachineCustomValidator
+		defaulter    *IroncoreMetalMachineCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		metalMachine = &infrav1alpha1.IroncoreMetalMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-machine",
+			},
+			Spec: infrav1alpha1.IroncoreMetalMachineSpec{
+				Image: "ghcr.io/ironcore-dev/os-images/gardenlinux:1443.3",
+				IPAMConfig: []infrav1alpha1.IPAMConfig{{
+					MetadataKey: "foo",
+					IPAMRef: &infrav1alpha1.IPAMObjectReference{
+						Name: "pool",
+						Kind: "GlobalInClusterIPPool",
+					},
+				}},
+				Metadata: &apiextensionsv1.JSON{Raw: []byte(`{"foo": "bar"}`)},
+			},
+		}
+		validator = &IroncoreMetalMachineCustomValidator{}
+		defaulter = &IroncoreMetalMachineCustomDefaulter{}
+	})
+
+	It("should default the API group of the IPAM references", func(ctx SpecContext) {
+		Expect(defaulter.Default(ctx, metalMachine)).To(Succeed())
+		Expect(metalMachine.Spec.IPAMConfig[0].IPAMRef.APIGroup).To(Equal("ipam.cluster.x-k8s.io"))
+	})
+
+	It("should accept a valid IroncoreMetalMachine", func(ctx SpecContext) {
+		_, err := validator.ValidateCreate(ctx, metalMachine)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject an IroncoreMetalMachine without an image", func(ctx SpecContext) {
+		metalMachine.Spec.Image = ""
+
+		_, err := validator.ValidateCreate(ctx, metalMachine)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("spec.image")))
+	})
+
+	It("should reject an IPAMConfig without an IPAM reference", func(ctx SpecContext) {
+		metalMachine.Spec.IPAMConfig[0].IPAMRef = nil
+
+		_, err := validator.ValidateCreate(ctx, metalMachine)
+		Expect(err).To(MatchError(ContainSubstring("spec.ipamConfig[0].ipamRef")))
+	})
+
+	It("should accept several IPAM references for dual-stack", func(ctx SpecContext) {
+		metalMachine.Spec.IPAMConfig[0].IPAMRefs = []infrav1alpha1.IPAMObjectReference{{
+			Name: "pool-v6",
+			Kind: "GlobalInClusterIPPool",
+		}}
+
+		Expect(defaulter.Default(ctx, metalMachine)).To(Succeed())
+		Expect(metalMachine.Spec.IPAMConfig[0].IPAMRefs[0].APIGroup).To(Equal("ipam.cluster.x-k8s.io"))
+		_, err := validator.ValidateCreate(ctx, metalMachine)
+		Expect(err).NotTo(HaveOccurred())
+
+		metalMachine.Spec.IPAMConfig[0].IPAMRef = nil
+		_, err = validator.ValidateCreate(ctx, metalMachine)
+		Expect(err).NotTo(HaveOccurred())
+
+		metalMachine.Spec.IPAMConfig[0].IPAMRefs[0].Name = ""
+		_, err = validator.ValidateCreate(ctx, metalMachine)
+		Expect(err).To(MatchError(ContainSubstring("spec.ipamConfig[0].ipamRefs[0].name")))
+	})
+
+	It("should accept a network config using the addresses of the IPAMConfig", func(ctx SpecContext) {
+		metalMachine.Spec.NetworkConfig = &infrav1alpha1.NetworkConfig{
+			Ethernets: []infrav1alpha1.NetworkEthernet{
+				{NetworkInterface: infrav1alpha1.NetworkInterface{Name: "eth0"}},
+				{NetworkInterface: infrav1alpha1.NetworkInterface{Name: "eth1"}},
+			},
+			Bonds: []infrav1alpha1.NetworkBond{{
+				NetworkInterface: infrav1alpha1.NetworkInterface{Name: "bond0"},
+				Interfaces:       []string{"eth0", "eth1"},
+			}},
+			VLANs: []infrav1alpha1.NetworkVLAN{{
+				NetworkInterface: infrav1alpha1.NetworkInterface{
+					Name:          "vlan100",
+					AddressesFrom: []string{"foo"},
+					Routes:        []infrav1alpha1.NetworkRoute{{To: "10.0.0.0/8", Via: "10.11.12.1"}},
+				},
+				ID:   100,
+				Link: "bond0",
+			}},
+		}
+
+		_, err := validator.ValidateCreate(ctx, metalMachine)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject a network config with unknown references", func(ctx SpecContext) {
+		metalMachine.Spec.NetworkConfig = &infrav1alpha1.NetworkConfig{
+			Ethernets: []infrav1alpha1.NetworkEthernet{{
+				NetworkInterface: infrav1alpha1.NetworkInterface{
+					Name:          "eth0",
+					AddressesFrom: []string{"bar"},
+					Routes:        []infrav1alpha1.NetworkRoute{{To: "10.0.0.1"}},
+				},
+			}},
+			Bonds: []infrav1alpha1.NetworkBond{{
+				NetworkInterface: infrav1alpha1.NetworkInterface{Name: "eth0"},
+				Interfaces:       []string{"eth1"},
+			}},
+			VLANs: []infrav1alpha1.NetworkVLAN{{
+				NetworkInterface: infrav1alpha1.NetworkInterface{Name: "vlan100"},
+				ID:               100,
+				Link:             "bond0",
+			}},
+		}
+
+		_, err := validator.ValidateCreate(ctx, metalMachine)
+		Expect(err).To(MatchError(ContainSubstring("spec.networkConfig.ethernets[0].addressesFrom[0]")))
+		Expect(err).To(MatchError(ContainSubstring("spec.networkConfig.ethernets[0].routes[0].to")))
+		Expect(err).To(MatchError(ContainSubstring("spec.networkConfig.bonds[0].name")))
+		Expect(err).To(MatchError(ContainSubstring("spec.networkConfig.bonds[0].interfaces[0]")))
+		Expect(err).To(MatchError(ContainSubstring("spec.networkConfig.vlans[0].link")))
+	})
+
+	It("should validate the power and Server operation annotations", func(ctx SpecContext) {
+		metalMachine.Annotations = map[string]string{
+			infrav1alpha1.PowerAnnotation:           "Off",
+			infrav1alpha1.ServerOperationAnnotation: "graceful-restart-server",
+		}
+		_, err := validator.ValidateCreate(ctx, metalMachine)
+		Expect(err).NotTo(HaveOccurred())
+
+		newMetalMachine := metalMachine.DeepCopy()
+		newMetalMachine.Annotations = map[string]string{
+			infrav1alpha1.PowerAnnotation:           "off",
+			infrav1alpha1.ServerOperationAnnotation: "reboot",
+		}
+		_, err = validator.ValidateUpdate(ctx, metalMachine, newMetalMachine)
+		Expect(err).To(MatchError(ContainSubstring("metadata.annotations[ironcoremetalmachine.infrastructure.cluster.x-k8s.io/power]")))
+		Expect(err).To(MatchError(ContainSubstring("metadata.annotations[ironcoremetalmachine.infrastructure.cluster.x-k8s.io/server-operation]")))
+	})
+
+	It("should reject duplicate metadata keys", func(ctx SpecContext) {
+		metalMachine.Spec.IPAMConfig = append(metalMachine.Spec.IPAMConfig, metalMachine.Spec.IPAMConfig[0])
+
+		_, err := validator.ValidateCreate(ctx, metalMachine)
+		Expect(err).To(MatchError(ContainSubstring(`spec.ipamConfig[1].metadataKey: Duplicate value: "foo"`)))
+	})
+
+	It("should reject metadata which is not a JSON object", func(ctx SpecContext) {
+		metalMachine.Spec.Metadata = &apiextensionsv1.JSON{Raw: []byte(`["foo"]`)}
+
+		_, err := validator.ValidateCreate(ctx, metalMachine)
+		Expect(err).To(MatchError(ContainSubstring("metadata must be a JSON object")))
+	})
+
+	It("should reject names which are too long for the derived objects", func(ctx SpecContext) {
+		metalMachine.Name = strings.Repeat("a", 250)
+
+		_, err := validator.ValidateCreate(ctx, metalMachine)
+		Expect(err).To(MatchError(ContainSubstring("metadata.name")))
+	})
+
+	It("should allow to set the provider ID once", func(ctx SpecContext) {
+		newMetalMachine := metalMachine.DeepCopy()
+		newMetalMachine.Spec.ProviderID = "metal://default/test-machine"
+
+		_, err := validator.ValidateUpdate(ctx, metalMachine, newMetalMachine)
+		Expect(err).NotTo(HaveOccurred())
+
+		changedMetalMachine := newMetalMachine.DeepCopy()
+		changedMetalMachine.Spec.ProviderID = "metal://default/other-machine"
+
+		_, err = validator.ValidateUpdate(ctx, newMetalMachine, changedMetalMachine)
+		Expect(err).To(MatchError(ContainSubstring("providerID is immutable once set")))
+	})
+
+	It("should allow to change the deletion policy", func(ctx SpecContext) {
+		newMetalMachine := metalMachine.DeepCopy()
+		newMetalMachine.Spec.DeletionPolicy = infrav1alpha1.DeletionPolicyReleaseAfterDiskWipe
+
+		_, err := validator.ValidateUpdate(ctx, metalMachine, newMetalMachine)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject changes of the spec", func(ctx SpecContext) {
+		newMetalMachine := metalMachine.DeepCopy()
+		newMetalMachine.Spec.Image = "ghcr.io/ironcore-dev/os-images/gardenlinux:1592.0"
+
+		_, err := validator.ValidateUpdate(ctx, metalMachine, newMetalMachine)
+		Expect(err).To(MatchError(ContainSubstring("spec is immutable except for providerID")))
+	})
+
+	It("should allow to update a machine which was created before a validation was added", func(ctx SpecContext) {
+		metalMachine.Spec.Image = ""
+		metalMachine.Spec.Metadata = &apiextensionsv1.JSON{Raw: []byte(`["foo"]`)}
+
+		newMetalMachine := metalMachine.DeepCopy()
+		newMetalMachine.Spec.ProviderID = "metal://default/test-machine"
+		newMetalMachine.Finalizers = []string{"test"}
+
+		_, err := validator.ValidateUpdate(ctx, metalMachine, newMetalMachine)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not validate a machine which is being deleted", func(ctx SpecContext) {
+		metalMachine.Spec.Image = ""
+		metalMachine.Annotations = map[string]string{infrav1alpha1.PowerAnnotation: "Unknown"}
+		metalMachine.DeletionTimestamp = ptr.To(metav1.Now())
+
+		newMetalMachine := metalMachine.DeepCopy()
+		newMetalMachine.Finalizers = nil
+		newMetalMachine.Annotations[infrav1alpha1.PowerAnnotation] = "Invalid"
+
+		_, err := validator.ValidateUpdate(ctx, metalMachine, newMetalMachine)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
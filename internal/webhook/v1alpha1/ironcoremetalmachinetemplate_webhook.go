@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupIroncoreMetalMachineTemplateWebhookWithManager registers the webhooks for IroncoreMetalMachineTemplate in the
+// manager.
+func SetupIroncoreMetalMachineTemplateWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &infrav1alpha1.IroncoreMetalMachineTemplate{}).
+		WithValidator(&IroncoreMetalMachineTemplateCustomValidator{}).
+		WithDefaulter(&IroncoreMetalMachineTemplateCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1alpha1-ironcoremetalmachinetemplate,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalmachinetemplates,verbs=create;update,versions=v1alpha1,name=mironcoremetalmachinetemplate-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// IroncoreMetalMachineTemplateCustomDefaulter sets the defaults of an IroncoreMetalMachineTemplate.
+type IroncoreMetalMachineTemplateCustomDefaulter struct{}
+
+var _ admission.Defaulter[*infrav1alpha1.IroncoreMetalMachineTemplate] = &IroncoreMetalMachineTemplateCustomDefaulter{}
+
+// Default implements admission.Defaulter.
+func (d *IroncoreMetalMachineTemplateCustomDefaulter) Default(_ context.Context, template *infrav1alpha1.IroncoreMetalMachineTemplate) error {
+	defaultIroncoreMetalMachineSpec(&template.Spec.Template.Spec)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-ironcoremetalmachinetemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ironcoremetalmachinetemplates,verbs=create;update,versions=v1alpha1,name=vironcoremetalmachinetemplate-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// IroncoreMetalMachineTemplateCustomValidator validates an IroncoreMetalMachineTemplate. The spec of a template is
+// immutable, changes are rolled out by creating a new template.
+type IroncoreMetalMachineTemplateCustomValidator struct{}
+
+var _ admission.Validator[*infrav1alpha1.IroncoreMetalMachineTemplate] = &IroncoreMetalMachineTemplateCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *IroncoreMetalMachineTemplateCustomValidator) ValidateCreate(_ context.Context, template *infrav1alpha1.IroncoreMetalMachineTemplate) (admission.Warnings, error) {
+	allErrs := validateIroncoreMetalMachineSpec(&template.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+	return nil, ironcoreMetalMachineTemplateInvalid(template, allErrs)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *IroncoreMetalMachineTemplateCustomValidator) ValidateUpdate(_ context.Context, oldTemplate, newTemplate *infrav1alpha1.IroncoreMetalMachineTemplate) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	if !equality.Semantic.DeepEqual(oldTemplate.Spec, newTemplate.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "IroncoreMetalMachineTemplate spec is immutable"))
+	}
+	return nil, ironcoreMetalMachineTemplateInvalid(newTemplate, allErrs)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *IroncoreMetalMachineTemplateCustomValidator) ValidateDelete(_ context.Context, _ *infrav1alpha1.IroncoreMetalMachineTemplate) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func ironcoreMetalMachineTemplateInvalid(template *infrav1alpha1.IroncoreMetalMachineTemplate, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(infrav1alpha1.GroupVersion.WithKind("IroncoreMetalMachineTemplate").GroupKind(), template.Name, allErrs)
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("IroncoreMetalMachineTemplate Webhook", func() {
+	var (
+		template  *infrav1alpha1.IroncoreMetalMachineTemplate
+		validator *IroncoreMetalMachineTemplateCustomValidator
+	)
+
+	BeforeEach(func() {
+		template = &infrav1alpha1.IroncoreMetalMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "test-template",
+			},
+			Spec: infrav1alpha1.IroncoreMetalMachineTemplateSpec{
+				Template: infrav1alpha1.IroncoreMetalMachineTemplateResource{
+					Spec: infrav1alpha1.IroncoreMetalMachineSpec{
+						Image: "ghcr.io/ironcore-dev/os-images/gardenlinux:1443.3",
+					},
+				},
+			},
+		}
+		validator = &IroncoreMetalMachineTemplateCustomValidator{}
+	})
+
+	It("should validate the spec of the template", func(ctx SpecContext) {
+		_, err := validator.ValidateCreate(ctx, template)
+		Expect(err).NotTo(HaveOccurred())
+
+		template.Spec.Template.Spec.Image = ""
+		_, err = validator.ValidateCreate(ctx, template)
+		Expect(err).To(MatchError(ContainSubstring("spec.template.spec.image")))
+	})
+
+	It("should reject changes of the spec", func(ctx SpecContext) {
+		newTemplate := template.DeepCopy()
+		newTemplate.Labels = map[string]string{"foo": "bar"}
+
+		_, err := validator.ValidateUpdate(ctx, template, newTemplate)
+		Expect(err).NotTo(HaveOccurred())
+
+		newTemplate.Spec.Template.Spec.Image = "ghcr.io/ironcore-dev/os-images/gardenlinux:1592.0"
+		_, err = validator.ValidateUpdate(ctx, template, newTemplate)
+		Expect(err).To(MatchError(ContainSubstring("IroncoreMetalMachineTemplate spec is immutable")))
+	})
+})
@@ -0,0 +1,370 @@
+// SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	infrav1alpha1 "github.com/ironcore-dev/cluster-api-provider-ironcore-metal/api/v1alpha1"
+	metalv1alpha1 "github.com/ironcore-dev/metal-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// ignitionSecretPrefix is the prefix of the ignition secrets rendered for the IroncoreMetalMachines.
+	ignitionSecretPrefix = "ignition-"
+
+	defaultIPAMAPIGroup             = "ipam.cluster.x-k8s.io"
+	defaultControlPlaneEndpointPort = 6443
+	// defaultKeepalivedControlPlaneEndpointPort is the default port of the control plane endpoint haproxy listens
+	// on, next to the kube-apiserver on the defaultControlPlaneEndpointPort.
+	defaultKeepalivedControlPlaneEndpointPort = 8443
+
+	// networkInterfaceNameMaxLength is the maximum length of a Linux network interface name.
+	networkInterfaceNameMaxLength = 15
+)
+
+// defaultIroncoreMetalMachineSpec sets the defaults of an IroncoreMetalMachineSpec.
+func defaultIroncoreMetalMachineSpec(spec *infrav1alpha1.IroncoreMetalMachineSpec) {
+	for i := range spec.IPAMConfig {
+		if ipamRef := spec.IPAMConfig[i].IPAMRef; ipamRef != nil && ipamRef.APIGroup == "" {
+			ipamRef.APIGroup = defaultIPAMAPIGroup
+		}
+		for j := range spec.IPAMConfig[i].IPAMRefs {
+			if ipamRef := &spec.IPAMConfig[i].IPAMRefs[j]; ipamRef.APIGroup == "" {
+				ipamRef.APIGroup = defaultIPAMAPIGroup
+			}
+		}
+	}
+}
+
+// validateIroncoreMetalMachineName validates that the name of the ignition secret derived from the name of an
+// IroncoreMetalMachine does not exceed the length of a DNS subdomain.
+func validateIroncoreMetalMachineName(name string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(ignitionSecretPrefix+name) > validation.DNS1123SubdomainMaxLength {
+		allErrs = append(allErrs, field.TooLong(fldPath, name, validation.DNS1123SubdomainMaxLength-len(ignitionSecretPrefix)))
+	}
+
+	return allErrs
+}
+
+// validateIroncoreMetalMachineAnnotations validates the values of the power and Server operation annotations of an
+// IroncoreMetalMachine.
+func validateIroncoreMetalMachineAnnotations(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if power, ok := annotations[infrav1alpha1.PowerAnnotation]; ok {
+		supported := []string{string(metalv1alpha1.PowerOn), string(metalv1alpha1.PowerOff)}
+		if !slices.Contains(supported, power) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Key(infrav1alpha1.PowerAnnotation), power, supported))
+		}
+	}
+	if operation, ok := annotations[infrav1alpha1.ServerOperationAnnotation]; ok && !slices.Contains(infrav1alpha1.ServerOperations, operation) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Key(infrav1alpha1.ServerOperationAnnotation), operation, infrav1alpha1.ServerOperations))
+	}
+
+	return allErrs
+}
+
+// validateIroncoreMetalMachineSpec validates an IroncoreMetalMachineSpec.
+func validateIroncoreMetalMachineSpec(spec *infrav1alpha1.IroncoreMetalMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.Image == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("image"), "image must be set"))
+	}
+
+	if spec.ServerSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(spec.ServerSelector, metav1validation.LabelSelectorValidationOptions{}, fldPath.Child("serverSelector"))...)
+	}
+
+	metadataKeys := sets.New[string]()
+	for i, ipamConfig := range spec.IPAMConfig {
+		idxPath := fldPath.Child("ipamConfig").Index(i)
+		switch {
+		case ipamConfig.MetadataKey == "":
+			allErrs = append(allErrs, field.Required(idxPath.Child("metadataKey"), "metadataKey must be set"))
+		case metadataKeys.Has(ipamConfig.MetadataKey):
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("metadataKey"), ipamConfig.MetadataKey))
+		default:
+			for _, msg := range validation.IsDNS1123Subdomain(ipamConfig.MetadataKey) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("metadataKey"), ipamConfig.MetadataKey, msg))
+			}
+		}
+		metadataKeys.Insert(ipamConfig.MetadataKey)
+
+		if ipamConfig.IPAMRef != nil || len(ipamConfig.IPAMRefs) == 0 {
+			allErrs = append(allErrs, validateIPAMObjectReference(ipamConfig.IPAMRef, idxPath.Child("ipamRef"))...)
+		}
+		for j := range ipamConfig.IPAMRefs {
+			allErrs = append(allErrs, validateIPAMObjectReference(&ipamConfig.IPAMRefs[j], idxPath.Child("ipamRefs").Index(j))...)
+		}
+	}
+
+	if spec.NetworkConfig != nil {
+		allErrs = append(allErrs, validateNetworkConfig(spec.NetworkConfig, metadataKeys, fldPath.Child("networkConfig"))...)
+	}
+
+	if spec.Metadata != nil {
+		metadata := make(map[string]any)
+		if err := json.Unmarshal(spec.Metadata.Raw, &metadata); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("metadata"), string(spec.Metadata.Raw), "metadata must be a JSON object"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateNetworkConfig validates the interfaces of a NetworkConfig and their relations. The addresses of the
+// interfaces have to be allocated for MetadataKeys of the IPAMConfig.
+func validateNetworkConfig(networkConfig *infrav1alpha1.NetworkConfig, metadataKeys sets.Set[string], fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := sets.New[string]()
+	ethernets := sets.New[string]()
+	links := sets.New[string]()
+	validateInterface := func(iface *infrav1alpha1.NetworkInterface, idxPath *field.Path) {
+		namePath := idxPath.Child("name")
+		switch {
+		case iface.Name == "":
+			allErrs = append(allErrs, field.Required(namePath, "name must be set"))
+		case names.Has(iface.Name):
+			allErrs = append(allErrs, field.Duplicate(namePath, iface.Name))
+		case len(iface.Name) > networkInterfaceNameMaxLength:
+			allErrs = append(allErrs, field.TooLong(namePath, iface.Name, networkInterfaceNameMaxLength))
+		case iface.Name == "." || iface.Name == ".." || strings.ContainsAny(iface.Name, "/: \t\n"):
+			allErrs = append(allErrs, field.Invalid(namePath, iface.Name, "name must be a valid network interface name"))
+		}
+		names.Insert(iface.Name)
+
+		for i, key := range iface.AddressesFrom {
+			if !metadataKeys.Has(key) {
+				allErrs = append(allErrs, field.NotFound(idxPath.Child("addressesFrom").Index(i), key))
+			}
+		}
+		for i, route := range iface.Routes {
+			routePath := idxPath.Child("routes").Index(i)
+			if _, err := netip.ParsePrefix(route.To); err != nil {
+				allErrs = append(allErrs, field.Invalid(routePath.Child("to"), route.To, "to must be a CIDR"))
+			}
+			if route.Via != "" && net.ParseIP(route.Via) == nil {
+				allErrs = append(allErrs, field.Invalid(routePath.Child("via"), route.Via, "via must be an IP address"))
+			}
+		}
+	}
+
+	for i := range networkConfig.Ethernets {
+		ethernet := &networkConfig.Ethernets[i]
+		validateInterface(&ethernet.NetworkInterface, fldPath.Child("ethernets").Index(i))
+		ethernets.Insert(ethernet.Name)
+		links.Insert(ethernet.Name)
+	}
+
+	members := sets.New[string]()
+	for i := range networkConfig.Bonds {
+		bond := &networkConfig.Bonds[i]
+		idxPath := fldPath.Child("bonds").Index(i)
+		validateInterface(&bond.NetworkInterface, idxPath)
+		links.Insert(bond.Name)
+		if len(bond.Interfaces) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("interfaces"), "interfaces must be set"))
+		}
+		for j, member := range bond.Interfaces {
+			memberPath := idxPath.Child("interfaces").Index(j)
+			switch {
+			case !ethernets.Has(member):
+				allErrs = append(allErrs, field.Invalid(memberPath, member, "interface must be an ethernet"))
+			case members.Has(member):
+				allErrs = append(allErrs, field.Duplicate(memberPath, member))
+			}
+			members.Insert(member)
+		}
+	}
+	for i, ethernet := range networkConfig.Ethernets {
+		if members.Has(ethernet.Name) && (ethernet.DHCP || len(ethernet.AddressesFrom) > 0 || len(ethernet.Routes) > 0) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ethernets").Index(i),
+				"an ethernet aggregated by a bond must not have addresses or routes"))
+		}
+	}
+
+	for i := range networkConfig.VLANs {
+		vlan := &networkConfig.VLANs[i]
+		idxPath := fldPath.Child("vlans").Index(i)
+		validateInterface(&vlan.NetworkInterface, idxPath)
+		if !links.Has(vlan.Link) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("link"), vlan.Link, "link must be an ethernet or bond"))
+		}
+	}
+
+	return allErrs
+}
+
+// ratchetErrors returns the errors of an updated object which the old object did not have already. A field which is
+// invalid according to a validation added after the object was created only has to be valid once it is changed.
+func ratchetErrors(oldErrs, newErrs field.ErrorList) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, newErr := range newErrs {
+		ratcheted := slices.ContainsFunc(oldErrs, func(oldErr *field.Error) bool {
+			return oldErr.Type == newErr.Type && oldErr.Field == newErr.Field && equality.Semantic.DeepEqual(oldErr.BadValue, newErr.BadValue)
+		})
+		if !ratcheted {
+			allErrs = append(allErrs, newErr)
+		}
+	}
+	return allErrs
+}
+
+// validateIroncoreMetalMachineSpecUpdate validates that only the ProviderID of an IroncoreMetalMachineSpec is set
+// after creation. All other fields are immutable.
+func validateIroncoreMetalMachineSpecUpdate(oldSpec, newSpec *infrav1alpha1.IroncoreMetalMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if oldSpec.ProviderID != "" && oldSpec.ProviderID != newSpec.ProviderID {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("providerID"), "providerID is immutable once set"))
+	}
+
+	// the deletion policy only takes effect on deletion and may be changed until then
+	oldSpecWithoutMutable, newSpecWithoutMutable := oldSpec.DeepCopy(), newSpec.DeepCopy()
+	oldSpecWithoutMutable.ProviderID, newSpecWithoutMutable.ProviderID = "", ""
+	oldSpecWithoutMutable.DeletionPolicy, newSpecWithoutMutable.DeletionPolicy = "", ""
+	if !equality.Semantic.DeepEqual(oldSpecWithoutMutable, newSpecWithoutMutable) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "spec is immutable except for providerID and deletionPolicy"))
+	}
+
+	return allErrs
+}
+
+// defaultIroncoreMetalClusterSpec sets the defaults of an IroncoreMetalClusterSpec.
+func defaultIroncoreMetalClusterSpec(spec *infrav1alpha1.IroncoreMetalClusterSpec) {
+	port := int32(defaultControlPlaneEndpointPort)
+	if spec.ControlPlaneLoadBalancer != nil && spec.ControlPlaneLoadBalancer.Type == infrav1alpha1.ControlPlaneLoadBalancerKeepalived {
+		port = defaultKeepalivedControlPlaneEndpointPort
+	}
+	if spec.ControlPlaneEndpoint.Host != "" && spec.ControlPlaneEndpoint.Port == 0 {
+		spec.ControlPlaneEndpoint.Port = port
+	}
+	if spec.ControlPlaneEndpointIPAM != nil {
+		if spec.ControlPlaneEndpointIPAM.Port == 0 {
+			spec.ControlPlaneEndpointIPAM.Port = port
+		}
+		if ipamRef := spec.ControlPlaneEndpointIPAM.IPAMRef; ipamRef != nil && ipamRef.APIGroup == "" {
+			ipamRef.APIGroup = defaultIPAMAPIGroup
+		}
+	}
+}
+
+// validateIroncoreMetalClusterSpec validates an IroncoreMetalClusterSpec.
+func validateIroncoreMetalClusterSpec(spec *infrav1alpha1.IroncoreMetalClusterSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.ControlPlaneEndpointIPAM != nil {
+		allErrs = append(allErrs, validateIPAMObjectReference(spec.ControlPlaneEndpointIPAM.IPAMRef, fldPath.Child("controlPlaneEndpointIPAM", "ipamRef"))...)
+	}
+
+	if loadBalancer := spec.ControlPlaneLoadBalancer; loadBalancer != nil {
+		lbPath := fldPath.Child("controlPlaneLoadBalancer")
+		if loadBalancer.Interface == "" {
+			allErrs = append(allErrs, field.Required(lbPath.Child("interface"), "interface must be set"))
+		}
+		if host := spec.ControlPlaneEndpoint.Host; host != "" && net.ParseIP(host) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("controlPlaneEndpoint", "host"), host,
+				"host must be an IP address to be announced by the control plane load balancer"))
+		}
+		if loadBalancer.Type == infrav1alpha1.ControlPlaneLoadBalancerKeepalived {
+			apiServerPort := loadBalancer.APIServerPort
+			if apiServerPort == 0 {
+				apiServerPort = defaultControlPlaneEndpointPort
+			}
+			port := spec.ControlPlaneEndpoint.Port
+			if port == 0 && spec.ControlPlaneEndpointIPAM != nil {
+				port = spec.ControlPlaneEndpointIPAM.Port
+			}
+			if port == apiServerPort {
+				allErrs = append(allErrs, field.Invalid(lbPath.Child("apiServerPort"), apiServerPort,
+					"apiServerPort must differ from the port of the control plane endpoint"))
+			}
+		}
+	}
+
+	for i, failureDomain := range spec.FailureDomains {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&failureDomain.ServerSelector,
+			metav1validation.LabelSelectorValidationOptions{}, fldPath.Child("failureDomains").Index(i).Child("serverSelector"))...)
+	}
+
+	if spec.DNS != nil {
+		dnsPath := fldPath.Child("dns")
+		for i, nameserver := range spec.DNS.Nameservers {
+			if net.ParseIP(nameserver) == nil {
+				allErrs = append(allErrs, field.Invalid(dnsPath.Child("nameservers").Index(i), nameserver, "nameserver must be an IP address"))
+			}
+		}
+		for i, searchDomain := range spec.DNS.SearchDomains {
+			for _, msg := range validation.IsDNS1123Subdomain(searchDomain) {
+				allErrs = append(allErrs, field.Invalid(dnsPath.Child("searchDomains").Index(i), searchDomain, msg))
+			}
+		}
+	}
+
+	if spec.NTP != nil {
+		for i, server := range spec.NTP.Servers {
+			if net.ParseIP(server) != nil {
+				continue
+			}
+			for _, msg := range validation.IsDNS1123Subdomain(server) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("ntp", "servers").Index(i), server, msg))
+			}
+		}
+	}
+
+	if ref := spec.MetalKubeconfigSecretRef; ref != nil && ref.TargetNamespace != "" {
+		for _, msg := range validation.IsDNS1123Label(ref.TargetNamespace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("metalKubeconfigSecretRef", "targetNamespace"), ref.TargetNamespace, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateIroncoreMetalClusterSpecUpdate validates that the control plane endpoint is not changed once it is set and
+// that the metal kubeconfig secret reference is neither added, changed nor removed, as the existing machines would lose
+// track of their ServerClaims.
+func validateIroncoreMetalClusterSpecUpdate(oldSpec, newSpec *infrav1alpha1.IroncoreMetalClusterSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if oldSpec.ControlPlaneEndpoint.Host != "" && oldSpec.ControlPlaneEndpoint != newSpec.ControlPlaneEndpoint {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlaneEndpoint"), "controlPlaneEndpoint is immutable once set"))
+	}
+	if !equality.Semantic.DeepEqual(oldSpec.MetalKubeconfigSecretRef, newSpec.MetalKubeconfigSecretRef) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("metalKubeconfigSecretRef"), "metalKubeconfigSecretRef is immutable"))
+	}
+
+	return allErrs
+}
+
+// validateIPAMObjectReference validates a reference to an IPAM pool.
+func validateIPAMObjectReference(ipamRef *infrav1alpha1.IPAMObjectReference, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if ipamRef == nil {
+		return append(allErrs, field.Required(fldPath, "ipamRef must be set"))
+	}
+	if ipamRef.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name must be set"))
+	}
+	if ipamRef.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("kind"), "kind must be set"))
+	}
+
+	return allErrs
+}